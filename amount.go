@@ -7,8 +7,12 @@ import (
 	"bytes"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/cockroachdb/apd/v3"
@@ -26,8 +30,21 @@ const (
 	RoundUp
 	// RoundDown rounds towards 0, truncating extra digits.
 	RoundDown
+	// RoundHalfEven rounds to the nearest even digit if the next digit is 5
+	// and there are no further digits, otherwise like RoundHalfUp.
+	// Also known as banker's rounding.
+	RoundHalfEven
 )
 
+// extModes maps rounding modes to their apd equivalents.
+var extModes = map[RoundingMode]apd.Rounder{
+	RoundHalfUp:   apd.RoundHalfUp,
+	RoundHalfDown: apd.RoundHalfDown,
+	RoundUp:       apd.RoundUp,
+	RoundDown:     apd.RoundDown,
+	RoundHalfEven: apd.RoundHalfEven,
+}
+
 // InvalidNumberError is returned when a numeric string can't be converted to a decimal.
 type InvalidNumberError struct {
 	Number string
@@ -38,6 +55,8 @@ func (e InvalidNumberError) Error() string {
 }
 
 // InvalidCurrencyCodeError is returned when a currency code is invalid or unrecognized.
+//
+// An empty currency code also matches ErrEmptyCurrency, via errors.Is.
 type InvalidCurrencyCodeError struct {
 	CurrencyCode string
 }
@@ -46,6 +65,11 @@ func (e InvalidCurrencyCodeError) Error() string {
 	return fmt.Sprintf("invalid currency code %q", e.CurrencyCode)
 }
 
+// Is reports whether target is ErrEmptyCurrency and the currency code is empty.
+func (e InvalidCurrencyCodeError) Is(target error) bool {
+	return target == ErrEmptyCurrency && e.CurrencyCode == ""
+}
+
 // MismatchError is returned when two amounts have mismatched currency codes.
 type MismatchError struct {
 	A Amount
@@ -56,23 +80,93 @@ func (e MismatchError) Error() string {
 	return fmt.Sprintf("amounts %q and %q have mismatched currency codes", e.A, e.B)
 }
 
+// NegativeAmountError is returned when an amount is negative, but must not be.
+type NegativeAmountError struct {
+	Amount Amount
+}
+
+func (e NegativeAmountError) Error() string {
+	return fmt.Sprintf("amount %q is negative", e.Amount)
+}
+
+// PrecisionError is returned when an amount has more fraction digits than allowed.
+type PrecisionError struct {
+	Amount Amount
+	Digits uint8
+}
+
+func (e PrecisionError) Error() string {
+	return fmt.Sprintf("amount %q has more than %d fraction digits", e.Amount, e.Digits)
+}
+
+// ErrEmptyCurrency is matched by the InvalidCurrencyCodeError returned
+// when an amount is created or decoded with an empty currency code.
+var ErrEmptyCurrency = errors.New("currency: empty currency code")
+
+// ErrNoAmounts is returned when a function requiring amounts receives none.
+var ErrNoAmounts = errors.New("currency: no amounts given")
+
+// ErrZeroQuantity is returned when quantities add up to zero.
+var ErrZeroQuantity = errors.New("currency: total quantity is zero")
+
 // Amount stores a decimal number with its currency code.
+//
+// Amounts are immutable. Operations never modify their operands,
+// they always return a new Amount instead.
+//
+// The zero value is "0" with an empty currency code. It can't be created
+// via constructors or decoding, which reject an empty currency code with an
+// error matching ErrEmptyCurrency. Operations combining it with other
+// amounts return a MismatchError, and ValidateAsPrice rejects it.
 type Amount struct {
 	number       apd.Decimal
 	currencyCode string
 }
 
 // NewAmount creates a new Amount from a numeric string and a currency code.
+//
+// The currency code is case-insensitive ("usd", "USD"), and is always
+// stored in its uppercase form, as returned by CurrencyCode.
+//
+// Surrounding whitespace is removed from the numeric string ("  12.34 ").
+// Whitespace inside the number ("1 2.34") results in an InvalidNumberError.
 func NewAmount(n, currencyCode string) (Amount, error) {
 	number := apd.Decimal{}
-	if _, _, err := number.SetString(n); err != nil {
+	if _, _, err := number.SetString(strings.TrimSpace(n)); err != nil || number.Form != apd.Finite {
 		return Amount{}, InvalidNumberError{n}
 	}
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return Amount{}, InvalidCurrencyCodeError{currencyCode}
 	}
 
-	return Amount{number, currencyCode}, nil
+	return Amount{number, canonicalCode(currencyCode)}, nil
+}
+
+// ParseAmount creates a new Amount from its string representation,
+// a numeric string and a currency code separated by a space ("1234.56 USD").
+//
+// The inverse of Amount.String. Locale-formatted numbers ("1,234.56")
+// are rejected, Formatter.Parse should be used for those instead.
+func ParseAmount(s string) (Amount, error) {
+	parts := strings.Split(s, " ")
+	if len(parts) != 2 || parts[0] == "" {
+		return Amount{}, InvalidNumberError{s}
+	}
+	return NewAmount(parts[0], parts[1])
+}
+
+// NewAmountFromLocalizedDigits creates a new Amount from a numeric string
+// which may contain localized digits (e.g. "١٢٣.٤٥") and a currency code.
+//
+// Digits from any supported numbering system are converted to ASCII.
+// Unlike Formatter.Parse, no locale is used: the decimal separator must be
+// ".", and grouping separators and symbols are not allowed.
+func NewAmountFromLocalizedDigits(n, currencyCode string) (Amount, error) {
+	a, err := NewAmount(asciiDigits.Replace(n), currencyCode)
+	if _, ok := err.(InvalidNumberError); ok {
+		return Amount{}, InvalidNumberError{n}
+	}
+	return a, err
 }
 
 // NewAmountFromBigInt creates a new Amount from a big.Int and a currency code.
@@ -87,7 +181,7 @@ func NewAmountFromBigInt(n *big.Int, currencyCode string) (Amount, error) {
 	coeff := new(apd.BigInt).SetMathBigInt(n)
 	number := apd.NewWithBigInt(coeff, -int32(d))
 
-	return Amount{*number, currencyCode}, nil
+	return Amount{*number, canonicalCode(currencyCode)}, nil
 }
 
 // NewAmountFromInt64 creates a new Amount from an int64 and a currency code.
@@ -99,7 +193,53 @@ func NewAmountFromInt64(n int64, currencyCode string) (Amount, error) {
 	number := apd.Decimal{}
 	number.SetFinite(n, -int32(d))
 
-	return Amount{number, currencyCode}, nil
+	return Amount{number, canonicalCode(currencyCode)}, nil
+}
+
+// NewAmountFromFixedPoint creates a new Amount from a fixed-point integer
+// at the given scale (e.g. 1234500000 at scale 8 is 12.345) and a currency code.
+//
+// The scale is independent of the currency's digits.
+func NewAmountFromFixedPoint(v *big.Int, scale uint8, currencyCode string) (Amount, error) {
+	if v == nil {
+		return Amount{}, InvalidNumberError{"nil"}
+	}
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+	coeff := new(apd.BigInt).SetMathBigInt(v)
+	number := apd.NewWithBigInt(coeff, -int32(scale))
+
+	return Amount{*number, canonicalCode(currencyCode)}, nil
+}
+
+// NewAmountFromMinorString creates a new Amount from a numeric string
+// of minor units (e.g. "1234" cents) and a currency code.
+//
+// The numeric string must be an integer, without a decimal point.
+func NewAmountFromMinorString(n, currencyCode string) (Amount, error) {
+	number := apd.Decimal{}
+	if strings.Contains(n, ".") {
+		return Amount{}, InvalidNumberError{n}
+	}
+	if _, _, err := number.SetString(n); err != nil || number.Form != apd.Finite || number.Exponent != 0 {
+		return Amount{}, InvalidNumberError{n}
+	}
+	d, ok := GetDigits(currencyCode)
+	if !ok {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+	number.Exponent = -int32(d)
+
+	return Amount{number, canonicalCode(currencyCode)}, nil
+}
+
+// Copy returns a copy of a that shares no state with it.
+func (a Amount) Copy() Amount {
+	result := apd.Decimal{}
+	result.Set(&a.number)
+
+	return Amount{result, a.currencyCode}
 }
 
 // Number returns the number as a numeric string.
@@ -117,6 +257,45 @@ func (a Amount) String() string {
 	return a.Number() + " " + a.CurrencyCode()
 }
 
+// StringWithDigits returns the fixed-scale canonical form of a,
+// with the number padded to the currency's digits ("5.00 USD", "5 JPY").
+//
+// Suitable for storing in DECIMAL columns. Unlike String, exponents
+// are never used. Any extra digits are kept, without rounding.
+func (a Amount) StringWithDigits() string {
+	major, minor := a.MajorMinor()
+	if minor == "" {
+		return major + " " + a.CurrencyCode()
+	}
+	return major + "." + minor + " " + a.CurrencyCode()
+}
+
+// Format formats a for the given locale, using the default formatter settings.
+//
+// Creates a new formatter on each call, so NewFormatter should be used
+// directly when formatting many amounts or customizing the output.
+func (a Amount) Format(locale Locale) string {
+	return NewFormatter(locale).Format(a)
+}
+
+// MajorMinor returns the integer and fraction digits of a, separately.
+//
+// The fraction digits are padded to the currency's digits, e.g. "12.5 USD"
+// results in "12" and "50". Any extra digits are kept, without rounding.
+// The sign is included in the integer digits ("-12", "50").
+func (a Amount) MajorMinor() (major string, minor string) {
+	digits, _ := GetDigits(a.currencyCode)
+	parts := strings.Split(a.number.Text('f'), ".")
+	major = parts[0]
+	if len(parts) == 2 {
+		minor = parts[1]
+	}
+	if len(minor) < int(digits) {
+		minor += strings.Repeat("0", int(digits)-len(minor))
+	}
+	return major, minor
+}
+
 // BigInt returns a in minor units, as a big.Int.
 func (a Amount) BigInt() *big.Int {
 	r := a.Round()
@@ -131,19 +310,125 @@ func (a Amount) Int64() (int64, error) {
 	return n.Int64()
 }
 
+// ToFixedPoint returns a as a fixed-point integer at the given scale,
+// i.e. the number multiplied by 10^scale (12.345 at scale 8 is 1234500000).
+//
+// The number is rounded half up if it has more fraction digits than scale.
+// Unlike RoundTo, a scale of 255 is not treated as DefaultDigits.
+func (a Amount) ToFixedPoint(scale uint8) (*big.Int, error) {
+	result := apd.Decimal{}
+	ctx := *decimalContext(&a.number)
+	ctx.Rounding = apd.RoundHalfUp
+	precision := a.number.NumDigits() + int64(a.number.Exponent) + int64(scale) + 1
+	if precision > int64(ctx.Precision) {
+		ctx.Precision = uint32(precision)
+	}
+	if _, err := ctx.Quantize(&result, &a.number, -int32(scale)); err != nil {
+		return nil, err
+	}
+	n := result.Coeff.MathBigInt()
+	if result.Negative {
+		n.Neg(n)
+	}
+
+	return n, nil
+}
+
+// ISO20022 returns a as an ISO 20022 amount (e.g. the value of
+// an ActiveCurrencyAndAmount element), such as "1234.56" or "1000".
+//
+// The number uses a period as the decimal separator, with no grouping
+// and no trailing zeroes. ISO 20022 only allows non-negative amounts,
+// with at most 18 digits, of which no more fraction digits than the
+// currency has. Otherwise a NegativeAmountError, InvalidNumberError or
+// PrecisionError is returned, respectively. The currency code is used
+// as-is for the Ccy attribute.
+func (a Amount) ISO20022() (string, error) {
+	if err := a.ValidateAsPriceStrict(); err != nil {
+		return "", err
+	}
+	number := apd.Decimal{}
+	number.Reduce(&a.number)
+	totalDigits := number.NumDigits()
+	if number.Exponent > 0 {
+		// Trailing zeroes of whole numbers (1E+3) count too.
+		totalDigits += int64(number.Exponent)
+	}
+	if totalDigits > 18 {
+		return "", InvalidNumberError{a.Number()}
+	}
+
+	return number.Text('f'), nil
+}
+
+// StoredAmount is a compact representation of an Amount, for storage.
+//
+// The number is stored as an integer (Units) and its number of
+// fraction digits (Scale), e.g. 12.345 is stored as 12345 and 3.
+type StoredAmount struct {
+	Units int64
+	Scale uint8
+	Code  string
+}
+
+// ToAmount converts s to an Amount.
+func (s StoredAmount) ToAmount() (Amount, error) {
+	if s.Code == "" || !IsValid(s.Code) {
+		return Amount{}, InvalidCurrencyCodeError{s.Code}
+	}
+	number := apd.Decimal{}
+	number.SetFinite(s.Units, -int32(s.Scale))
+
+	return Amount{number, canonicalCode(s.Code)}, nil
+}
+
+// ToStored converts a to a StoredAmount, keeping all of its fraction digits.
+// If a cannot be represented in a StoredAmount, an error is returned.
+func (a Amount) ToStored() (StoredAmount, error) {
+	number := apd.Decimal{}
+	if a.number.Exponent > 0 {
+		ctx := decimalContext(&a.number)
+		ctx.Quantize(&number, &a.number, 0)
+	} else {
+		number.Set(&a.number)
+	}
+	if number.Exponent < -255 || number.Form != apd.Finite {
+		return StoredAmount{}, InvalidNumberError{a.Number()}
+	}
+	scale := uint8(-number.Exponent)
+	number.Exponent = 0
+	units, err := number.Int64()
+	if err != nil {
+		return StoredAmount{}, InvalidNumberError{a.Number()}
+	}
+
+	return StoredAmount{units, scale, a.currencyCode}, nil
+}
+
+// WithCurrency returns a copy of a with a different currency code.
+//
+// Note that the number is NOT converted, use Convert for that.
+// Meant for correcting amounts stored with the wrong currency code.
+func (a Amount) WithCurrency(currencyCode string) (Amount, error) {
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return Amount{}, InvalidCurrencyCodeError{currencyCode}
+	}
+	return Amount{a.Copy().number, canonicalCode(currencyCode)}, nil
+}
+
 // Convert converts a to a different currency.
 func (a Amount) Convert(currencyCode, rate string) (Amount, error) {
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return Amount{}, InvalidCurrencyCodeError{currencyCode}
 	}
 	result := apd.Decimal{}
-	if _, _, err := result.SetString(rate); err != nil {
+	if _, _, err := result.SetString(rate); err != nil || result.Form != apd.Finite {
 		return Amount{}, InvalidNumberError{rate}
 	}
 	ctx := decimalContext(&a.number, &result)
 	ctx.Mul(&result, &a.number, &result)
 
-	return Amount{result, currencyCode}, nil
+	return Amount{result, canonicalCode(currencyCode)}, nil
 }
 
 // Add adds a and b together and returns the result.
@@ -173,7 +458,7 @@ func (a Amount) Sub(b Amount) (Amount, error) {
 // Mul multiplies a by n and returns the result.
 func (a Amount) Mul(n string) (Amount, error) {
 	result := apd.Decimal{}
-	if _, _, err := result.SetString(n); err != nil {
+	if _, _, err := result.SetString(n); err != nil || result.Form != apd.Finite {
 		return Amount{}, InvalidNumberError{n}
 	}
 	ctx := decimalContext(&a.number, &result)
@@ -185,7 +470,7 @@ func (a Amount) Mul(n string) (Amount, error) {
 // Div divides a by n and returns the result.
 func (a Amount) Div(n string) (Amount, error) {
 	result := apd.Decimal{}
-	if _, _, err := result.SetString(n); err != nil {
+	if _, _, err := result.SetString(n); err != nil || result.Form != apd.Finite {
 		return Amount{}, InvalidNumberError{n}
 	}
 	if result.IsZero() {
@@ -198,30 +483,321 @@ func (a Amount) Div(n string) (Amount, error) {
 	return Amount{result, a.currencyCode}, nil
 }
 
+// MulBigInt multiplies a by the integer n and returns the result.
+func (a Amount) MulBigInt(n *big.Int) (Amount, error) {
+	if n == nil {
+		return Amount{}, InvalidNumberError{"nil"}
+	}
+	coeff := new(apd.BigInt).SetMathBigInt(n)
+	result := apd.NewWithBigInt(coeff, 0)
+	ctx := decimalContext(&a.number, result)
+	ctx.Mul(result, &a.number, result)
+
+	return Amount{*result, a.currencyCode}, nil
+}
+
+// DivInt divides a by the integer n and returns the result.
+//
+// Unlike AllocateWithRemainder, the result is not rounded.
+func (a Amount) DivInt(n int64) (Amount, error) {
+	if n == 0 {
+		return Amount{}, InvalidNumberError{"0"}
+	}
+	result := apd.Decimal{}
+	result.SetInt64(n)
+	ctx := decimalContext(&a.number, &result)
+	ctx.Quo(&result, &a.number, &result)
+	result.Reduce(&result)
+
+	return Amount{result, a.currencyCode}, nil
+}
+
+// AddRounded adds a and b together and returns the result,
+// rounded to the currency's digits.
+//
+// Used for step-wise rounding, where each intermediate result must be
+// rounded (e.g. to the cent), instead of only the final result.
+func (a Amount) AddRounded(b Amount) (Amount, error) {
+	result, err := a.Add(b)
+	if err != nil {
+		return Amount{}, err
+	}
+	return result.Round(), nil
+}
+
+// SubRounded subtracts b from a and returns the result,
+// rounded to the currency's digits.
+func (a Amount) SubRounded(b Amount) (Amount, error) {
+	result, err := a.Sub(b)
+	if err != nil {
+		return Amount{}, err
+	}
+	return result.Round(), nil
+}
+
+// MulRounded multiplies a by n and returns the result,
+// rounded to the currency's digits.
+func (a Amount) MulRounded(n string) (Amount, error) {
+	result, err := a.Mul(n)
+	if err != nil {
+		return Amount{}, err
+	}
+	return result.Round(), nil
+}
+
+// MulRound multiplies a by n and returns the result,
+// rounded to the given number of fraction digits.
+//
+// Unlike MulRounded, the digits and rounding mode are explicit.
+func (a Amount) MulRound(n string, digits uint8, mode RoundingMode) (Amount, error) {
+	result, err := a.Mul(n)
+	if err != nil {
+		return Amount{}, err
+	}
+	return result.RoundTo(digits, mode), nil
+}
+
+// AddPercent increases a by the given percentage and returns the result.
+//
+// For example, 100 USD increased by 20 (percent) is 120 USD.
+func (a Amount) AddPercent(p string) (Amount, error) {
+	return a.applyPercent(p, false)
+}
+
+// SubtractPercent decreases a by the given percentage and returns the result.
+//
+// For example, 100 USD decreased by 10 (percent) is 90 USD.
+func (a Amount) SubtractPercent(p string) (Amount, error) {
+	return a.applyPercent(p, true)
+}
+
+// applyPercent multiplies a by (1 + p/100), or (1 - p/100) when subtracting.
+func (a Amount) applyPercent(p string, subtract bool) (Amount, error) {
+	factor := apd.Decimal{}
+	if _, _, err := factor.SetString(p); err != nil || factor.Form != apd.Finite {
+		return Amount{}, InvalidNumberError{p}
+	}
+	if subtract {
+		factor.Neg(&factor)
+	}
+	factor.Exponent -= 2
+	ctx := decimalContext(&a.number, &factor)
+	ctx.Add(&factor, apd.New(1, 0), &factor)
+	result := apd.Decimal{}
+	ctx.Mul(&result, &a.number, &factor)
+	// Remove any trailing zeroes past the original number of digits.
+	reduced := apd.Decimal{}
+	reduced.Reduce(&result)
+	exponent := a.number.Exponent
+	if reduced.Exponent < exponent {
+		exponent = reduced.Exponent
+	}
+	if exponent > result.Exponent {
+		ctx.Quantize(&result, &reduced, exponent)
+	}
+
+	return Amount{result, a.currencyCode}, nil
+}
+
+// AllocateWithRemainder splits a into n shares that add up to a.
+//
+// The amount is divided in the smallest unit of its currency (e.g. cents),
+// or in the smallest unit of the amount itself, if it is more precise.
+// The remainder left over after the division is distributed one unit at
+// a time, starting from the first share. The number of shares which
+// received an extra unit is returned as extraUnits.
+//
+// For example, 10.00 USD split into 3 results in 3.34, 3.33, 3.33,
+// with the first share receiving the extra cent (extraUnits == 1).
+func (a Amount) AllocateWithRemainder(n int) (shares []Amount, extraUnits int, err error) {
+	return a.allocate(n, false)
+}
+
+// SplitBalanced splits a into n shares that add up to a, spreading
+// the remainder evenly across the shares.
+//
+// Like AllocateWithRemainder, but the shares receiving an extra unit are
+// spaced apart instead of grouped at the front, which reduces the
+// period-to-period variation of recurring payments. For example,
+// 99.99 USD split into 12 gives the extra cents to shares 1, 5 and 9.
+// A single extra unit always goes to the first share.
+func (a Amount) SplitBalanced(n int) ([]Amount, error) {
+	shares, _, err := a.allocate(n, true)
+	return shares, err
+}
+
+// allocate splits a into n shares, distributing the remainder
+// from the first share, either consecutively or evenly spaced.
+func (a Amount) allocate(n int, balanced bool) (shares []Amount, extraUnits int, err error) {
+	if n <= 0 {
+		return nil, 0, InvalidNumberError{strconv.Itoa(n)}
+	}
+	exponent := a.number.Exponent
+	if digits, _ := GetDigits(a.currencyCode); -int32(digits) < exponent {
+		exponent = -int32(digits)
+	}
+	number := apd.Decimal{}
+	ctx := *decimalContext(&a.number)
+	// Ensure that the precision is sufficient for very large numbers.
+	precision := a.number.NumDigits() + int64(a.number.Exponent) - int64(exponent)
+	if precision > int64(ctx.Precision) {
+		ctx.Precision = uint32(precision)
+	}
+	if _, err := ctx.Quantize(&number, &a.number, exponent); err != nil {
+		return nil, 0, err
+	}
+	units := number.Coeff.MathBigInt()
+	quo, rem := new(big.Int).QuoRem(units, big.NewInt(int64(n)), new(big.Int))
+	extraUnits = int(rem.Int64())
+
+	shares = make([]Amount, 0, n)
+	for i := 0; i < n; i++ {
+		coeff := new(apd.BigInt).SetMathBigInt(quo)
+		extra := i < extraUnits
+		if balanced {
+			// Exactly extraUnits shares match, spaced n/extraUnits apart.
+			extra = int64(i)*int64(extraUnits)%int64(n) < int64(extraUnits)
+		}
+		if extra {
+			coeff.Add(coeff, apd.NewBigInt(1))
+		}
+		share := apd.NewWithBigInt(coeff, exponent)
+		share.Negative = number.Negative && !share.IsZero()
+		shares = append(shares, Amount{*share, a.currencyCode})
+	}
+
+	return shares, extraUnits, nil
+}
+
+// SplitByDenomination returns how many whole denominations fit into a,
+// and the remaining amount.
+//
+// For example, 85 USD split by 20 USD results in 4 and 5 USD.
+// Negative amounts result in a negative count and remainder.
+func (a Amount) SplitByDenomination(denomination Amount) (count int, remainder Amount, err error) {
+	if a.currencyCode != denomination.currencyCode {
+		return 0, Amount{}, MismatchError{a, denomination}
+	}
+	if !denomination.IsPositive() {
+		return 0, Amount{}, InvalidNumberError{denomination.Number()}
+	}
+	quo := apd.Decimal{}
+	rem := apd.Decimal{}
+	ctx := decimalContext(&a.number, &denomination.number)
+	if _, err := ctx.QuoInteger(&quo, &a.number, &denomination.number); err != nil {
+		return 0, Amount{}, InvalidNumberError{a.Number()}
+	}
+	ctx.Rem(&rem, &a.number, &denomination.number)
+	n, err := quo.Int64()
+	if err != nil || int64(int(n)) != n {
+		return 0, Amount{}, InvalidNumberError{quo.String()}
+	}
+
+	return int(n), Amount{rem, a.currencyCode}, nil
+}
+
 // Round is a shortcut for RoundTo(currency.DefaultDigits, currency.RoundHalfUp).
 func (a Amount) Round() Amount {
 	return a.RoundTo(DefaultDigits, RoundHalfUp)
 }
 
+// RoundBankers is a shortcut for RoundTo(digits, currency.RoundHalfEven).
+func (a Amount) RoundBankers(digits uint8) Amount {
+	return a.RoundTo(digits, RoundHalfEven)
+}
+
+// Ceil rounds a up to the next whole unit (towards +∞), e.g. 12.01 => 13.
+func (a Amount) Ceil() Amount {
+	return a.roundWhole(false)
+}
+
+// Floor rounds a down to the previous whole unit (towards -∞), e.g. 12.99 => 12.
+func (a Amount) Floor() Amount {
+	return a.roundWhole(true)
+}
+
+// roundWhole rounds a to a whole unit, towards -∞ if floor is true,
+// towards +∞ otherwise.
+//
+// Truncates and then adjusts by one unit, since apd's RoundUp doesn't
+// round up when all digits are discarded (0.01 => 0).
+func (a Amount) roundWhole(floor bool) Amount {
+	r := a.RoundTo(0, RoundDown)
+	if r.number.Cmp(&a.number) != 0 && a.number.Negative == floor {
+		one := apd.New(1, 0)
+		ctx := *decimalContext(&r.number)
+		// Account for an extra digit in case of a carry (99 => 100).
+		if precision := r.number.NumDigits() + 1; precision > int64(ctx.Precision) {
+			ctx.Precision = uint32(precision)
+		}
+		if floor {
+			ctx.Sub(&r.number, &r.number, one)
+		} else {
+			ctx.Add(&r.number, &r.number, one)
+		}
+	}
+	if r.number.IsZero() {
+		// Avoid returning -0 for amounts between -1 and 0.
+		r.number.Negative = false
+	}
+	return r
+}
+
 // RoundTo rounds a to the given number of fraction digits.
 func (a Amount) RoundTo(digits uint8, mode RoundingMode) Amount {
 	if digits == DefaultDigits {
 		digits, _ = GetDigits(a.currencyCode)
 	}
-	extModes := map[RoundingMode]apd.Rounder{
-		RoundHalfUp:   apd.RoundHalfUp,
-		RoundHalfDown: apd.RoundHalfDown,
-		RoundUp:       apd.RoundUp,
-		RoundDown:     apd.RoundDown,
+	result := apd.Decimal{}
+	ctx := *decimalContext(&a.number)
+	ctx.Rounding = extModes[mode]
+	// Ensure that the precision is sufficient for very large numbers,
+	// accounting for an extra digit in case of a carry (9.99 => 10.0).
+	precision := a.number.NumDigits() + int64(a.number.Exponent) + int64(digits) + 1
+	if precision > int64(ctx.Precision) {
+		ctx.Precision = uint32(precision)
+	}
+	if _, err := ctx.Quantize(&result, &a.number, -int32(digits)); err != nil {
+		// The number is too large to be padded with fraction digits
+		// (e.g. "1E+100000"), and has none to round, keep it as-is.
+		return a
+	}
+
+	return Amount{result, a.currencyCode}
+}
+
+// roundSignificant rounds a to the given number of significant digits.
+//
+// The exponent is kept when no rounding is needed, e.g. "1.20" stays as-is
+// when rounded to 3 significant digits, while "12345" becomes "1.235E+4"
+// when rounded to 4.
+func (a Amount) roundSignificant(digits uint8, mode RoundingMode) Amount {
+	if digits == 0 || a.number.NumDigits() <= int64(digits) {
+		return a
 	}
 	result := apd.Decimal{}
-	ctx := decimalContext(&a.number)
+	ctx := *decimalContext(&a.number)
+	ctx.Precision = uint32(digits)
 	ctx.Rounding = extModes[mode]
-	ctx.Quantize(&result, &a.number, -int32(digits))
+	ctx.Round(&result, &a.number)
 
 	return Amount{result, a.currencyCode}
 }
 
+// WouldRound returns whether rounding a to the given number of fraction
+// digits would change its value.
+//
+// Trailing zeroes are ignored, e.g. "1.0050" would be changed by rounding
+// to 2 digits, but not by rounding to 3 digits.
+func (a Amount) WouldRound(digits uint8) bool {
+	if digits == DefaultDigits {
+		digits, _ = GetDigits(a.currencyCode)
+	}
+	number := apd.Decimal{}
+	number.Reduce(&a.number)
+	return int64(-number.Exponent) > int64(digits)
+}
+
 // Cmp compares a and b and returns:
 //
 //   -1 if a <  b
@@ -243,6 +819,34 @@ func (a Amount) Equal(b Amount) bool {
 	return a.number.Cmp(&b.number) == 0
 }
 
+// ComparisonResult is the result of comparing two amounts, via Compare.
+type ComparisonResult struct {
+	// Sign is the sign of the difference: -1, 0 or +1.
+	Sign int
+	// Diff is the absolute difference, e.g. "1.50 USD".
+	Diff Amount
+	// Equal is true if the two amounts are equal.
+	Equal bool
+}
+
+// Compare compares a and b, returning the sign of their difference
+// together with the absolute difference.
+//
+// For example, comparing "3.33 USD" and "5.00 USD" gives a sign of -1
+// and a difference of "1.67 USD".
+func (a Amount) Compare(b Amount) (ComparisonResult, error) {
+	if a.currencyCode != b.currencyCode {
+		return ComparisonResult{}, MismatchError{a, b}
+	}
+	diff := apd.Decimal{}
+	ctx := decimalContext(&a.number, &b.number)
+	ctx.Sub(&diff, &a.number, &b.number)
+	sign := diff.Sign()
+	diff.Negative = false
+
+	return ComparisonResult{sign, Amount{diff, a.currencyCode}, sign == 0}, nil
+}
+
 // IsPositive returns whether a is positive.
 func (a Amount) IsPositive() bool {
 	zero := apd.New(0, 0)
@@ -261,6 +865,89 @@ func (a Amount) IsZero() bool {
 	return a.number.Cmp(zero) == 0
 }
 
+// OrderOfMagnitude returns the base-10 exponent of the magnitude of a,
+// e.g. 3 for 1234.56 and -2 for 0.05. The sign is ignored.
+//
+// Zero amounts have no order of magnitude, and return math.MinInt32.
+func (a Amount) OrderOfMagnitude() int {
+	if a.IsZero() {
+		return math.MinInt32
+	}
+	return int(a.number.NumDigits() + int64(a.number.Exponent) - 1)
+}
+
+// IsInteger returns whether a has no fractional component.
+//
+// Trailing zeroes are ignored, e.g. "5.00" is an integer, "5.50" is not.
+func (a Amount) IsInteger() bool {
+	number := apd.Decimal{}
+	number.Reduce(&a.number)
+	return number.Exponent >= 0
+}
+
+// IsWhole returns whether a has no fractional component
+// when rounded to its currency's digits.
+//
+// Unlike IsInteger, the currency is taken into account: amounts in
+// currencies without fraction digits (e.g. JPY) are always whole,
+// while "12.00 USD" is whole and "12.50 USD" is not.
+// Useful for hiding the fraction of whole amounts.
+func (a Amount) IsWhole() bool {
+	return a.Round().IsInteger()
+}
+
+// FitsCurrencyPrecision returns whether a has no more fraction digits
+// than its currency, e.g. "12.34 USD" fits, but "12.345 USD" does not.
+//
+// Trailing zeroes are ignored, e.g. "12.340 USD" fits.
+func (a Amount) FitsCurrencyPrecision() bool {
+	return !a.WouldRound(DefaultDigits)
+}
+
+// AssertCurrencyPrecision returns a PrecisionError if a has more
+// fraction digits than its currency.
+//
+// Useful for catching precision bugs before storage, instead of
+// rounding silently.
+func (a Amount) AssertCurrencyPrecision() error {
+	if !a.FitsCurrencyPrecision() {
+		digits, _ := GetDigits(a.currencyCode)
+		return PrecisionError{a, digits}
+	}
+	return nil
+}
+
+// ValidateAsPrice checks whether a can be used as a price.
+//
+// Returns an InvalidCurrencyCodeError if a has no currency code,
+// or a NegativeAmountError if a is negative. Prices with more fraction
+// digits than their currency (e.g. "0.005 USD") are allowed,
+// use ValidateAsPriceStrict to disallow them.
+func (a Amount) ValidateAsPrice() error {
+	if a.currencyCode == "" {
+		return InvalidCurrencyCodeError{a.currencyCode}
+	}
+	if a.IsNegative() {
+		return NegativeAmountError{a}
+	}
+	return nil
+}
+
+// ValidateAsPriceStrict is like ValidateAsPrice, but also returns a
+// PrecisionError if a has more fraction digits than its currency.
+//
+// Trailing zeroes are ignored, e.g. "5.000 USD" is a valid price.
+func (a Amount) ValidateAsPriceStrict() error {
+	if err := a.ValidateAsPrice(); err != nil {
+		return err
+	}
+	if a.WouldRound(DefaultDigits) {
+		digits, _ := GetDigits(a.currencyCode)
+		return PrecisionError{a, digits}
+	}
+	return nil
+}
+
 // MarshalBinary implements the encoding.BinaryMarshaler interface.
 func (a Amount) MarshalBinary() ([]byte, error) {
 	buf := bytes.Buffer{}
@@ -278,14 +965,14 @@ func (a *Amount) UnmarshalBinary(data []byte) error {
 	n := string(data[3:])
 	currencyCode := string(data[0:3])
 	number := apd.Decimal{}
-	if _, _, err := number.SetString(n); err != nil {
+	if _, _, err := number.SetString(n); err != nil || number.Form != apd.Finite {
 		return InvalidNumberError{n}
 	}
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return InvalidCurrencyCodeError{currencyCode}
 	}
 	a.number = number
-	a.currencyCode = currencyCode
+	a.currencyCode = canonicalCode(currencyCode)
 
 	return nil
 }
@@ -304,22 +991,32 @@ func (a Amount) MarshalJSON() ([]byte, error) {
 // UnmarshalJSON implements the json.Unmarshaler interface.
 func (a *Amount) UnmarshalJSON(data []byte) error {
 	aux := struct {
-		Number       string `json:"number"`
-		CurrencyCode string `json:"currency"`
+		Number       json.RawMessage `json:"number"`
+		CurrencyCode string          `json:"currency"`
 	}{}
 	err := json.Unmarshal(data, &aux)
 	if err != nil {
 		return err
 	}
+	// The number is usually a string, but can also be a JSON number,
+	// which is used as-is, to avoid losing precision via float64.
+	var n string
+	if len(aux.Number) > 0 && aux.Number[0] == '"' {
+		if err := json.Unmarshal(aux.Number, &n); err != nil {
+			return err
+		}
+	} else if string(aux.Number) != "null" {
+		n = string(aux.Number)
+	}
 	number := apd.Decimal{}
-	if _, _, err := number.SetString(aux.Number); err != nil {
-		return InvalidNumberError{aux.Number}
+	if _, _, err := number.SetString(n); err != nil || number.Form != apd.Finite {
+		return InvalidNumberError{n}
 	}
 	if aux.CurrencyCode == "" || !IsValid(aux.CurrencyCode) {
 		return InvalidCurrencyCodeError{aux.CurrencyCode}
 	}
 	a.number = number
-	a.currencyCode = aux.CurrencyCode
+	a.currencyCode = canonicalCode(aux.CurrencyCode)
 
 	return nil
 }
@@ -345,18 +1042,150 @@ func (a *Amount) Scan(src interface{}) error {
 	n := values[0]
 	currencyCode := values[1]
 	number := apd.Decimal{}
-	if _, _, err := number.SetString(n); err != nil {
+	if _, _, err := number.SetString(n); err != nil || number.Form != apd.Finite {
 		return InvalidNumberError{n}
 	}
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return InvalidCurrencyCodeError{currencyCode}
 	}
 	a.number = number
-	a.currencyCode = currencyCode
+	a.currencyCode = canonicalCode(currencyCode)
 
 	return nil
 }
 
+// CommonScale returns the largest number of fraction digits among amounts.
+//
+// Useful for displaying a list of amounts with the same number of digits,
+// by passing the result to Formatter.MinDigits or Amount.RoundTo.
+// All amounts must have the same currency code.
+func CommonScale(amounts ...Amount) (uint8, error) {
+	if len(amounts) == 0 {
+		return 0, ErrNoAmounts
+	}
+	var scale int32
+	for _, a := range amounts {
+		if a.currencyCode != amounts[0].currencyCode {
+			return 0, MismatchError{amounts[0], a}
+		}
+		if -a.number.Exponent > scale {
+			scale = -a.number.Exponent
+		}
+	}
+	if scale > 255 {
+		scale = 255
+	}
+
+	return uint8(scale), nil
+}
+
+// RoundAll rounds each amount to its currency's digits, using the given mode.
+//
+// Returns rounded copies, the given amounts are not modified.
+// Returns an InvalidCurrencyCodeError if an amount has no currency code
+// (e.g. a zero Amount{}).
+func RoundAll(amounts []Amount, mode RoundingMode) ([]Amount, error) {
+	result := make([]Amount, len(amounts))
+	for i, a := range amounts {
+		digits, ok := GetDigits(a.currencyCode)
+		if !ok {
+			return nil, InvalidCurrencyCodeError{a.currencyCode}
+		}
+		result[i] = a.RoundTo(digits, mode)
+	}
+
+	return result, nil
+}
+
+// PriceQuantity is a price paired with a quantity, used by WeightedAverage.
+//
+// The quantity is a decimal string, to allow fractional units.
+type PriceQuantity struct {
+	Price Amount
+	Qty   string
+}
+
+// WeightedAverage returns the average price weighted by quantity.
+//
+// Computed as sum(price * qty) / sum(qty), without rounding.
+// All prices must have the same currency code.
+func WeightedAverage(items []PriceQuantity) (Amount, error) {
+	if len(items) == 0 {
+		return Amount{}, ErrNoAmounts
+	}
+	ctx := decimalContextPrecision39
+	total := apd.Decimal{}
+	totalQty := apd.Decimal{}
+	for _, item := range items {
+		if item.Price.currencyCode != items[0].Price.currencyCode {
+			return Amount{}, MismatchError{items[0].Price, item.Price}
+		}
+		qty := apd.Decimal{}
+		if _, _, err := qty.SetString(item.Qty); err != nil || qty.Form != apd.Finite {
+			return Amount{}, InvalidNumberError{item.Qty}
+		}
+		product := apd.Decimal{}
+		ctx.Mul(&product, &item.Price.number, &qty)
+		ctx.Add(&total, &total, &product)
+		ctx.Add(&totalQty, &totalQty, &qty)
+	}
+	if totalQty.IsZero() {
+		return Amount{}, ErrZeroQuantity
+	}
+	result := apd.Decimal{}
+	ctx.Quo(&result, &total, &totalQty)
+	result.Reduce(&result)
+	if result.Exponent > 0 {
+		ctx.Quantize(&result, &result, 0)
+	}
+
+	return Amount{result, items[0].Price.currencyCode}, nil
+}
+
+// MakeChange breaks a down into the given denominations, largest first.
+//
+// Returns the count of each used denomination, and the remainder that
+// the denominations could not represent. Counts are keyed by number,
+// without trailing zeroes ("0.10" => "0.1"), so that denominations of
+// different scales ("1", "1.00") share a key.
+// The greedy approach does not guarantee the fewest pieces for
+// unusual denomination sets (e.g. 0.30 with 0.25 and 0.10).
+// All denominations must have the same currency code as a.
+func MakeChange(a Amount, denominations []Amount) (map[string]int, Amount, error) {
+	if a.IsNegative() {
+		return nil, Amount{}, NegativeAmountError{a}
+	}
+	sorted := make([]Amount, len(denominations))
+	for i, d := range denominations {
+		if d.currencyCode != a.currencyCode {
+			return nil, Amount{}, MismatchError{a, d}
+		}
+		if !d.IsPositive() {
+			return nil, Amount{}, InvalidNumberError{d.Number()}
+		}
+		sorted[i] = d
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].number.Cmp(&sorted[j].number) > 0
+	})
+	counts := make(map[string]int)
+	remainder := a
+	for _, d := range sorted {
+		count, rem, err := remainder.SplitByDenomination(d)
+		if err != nil {
+			return nil, Amount{}, err
+		}
+		if count > 0 {
+			key := apd.Decimal{}
+			key.Reduce(&d.number)
+			counts[key.Text('f')] += count
+		}
+		remainder = rem
+	}
+
+	return counts, remainder, nil
+}
+
 var (
 	decimalContextPrecision19 = apd.BaseContext.WithPrecision(19)
 	decimalContextPrecision39 = apd.BaseContext.WithPrecision(39)
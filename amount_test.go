@@ -5,7 +5,10 @@ package currency_test
 
 import (
 	"encoding/json"
+	"errors"
+	"math"
 	"math/big"
+	"reflect"
 	"testing"
 
 	"github.com/bojanz/currency"
@@ -25,18 +28,33 @@ func TestNewAmount(t *testing.T) {
 		t.Errorf("got %T, want currency.InvalidNumberError", err)
 	}
 
-	_, err = currency.NewAmount("10.99", "usd")
+	_, err = currency.NewAmount("10.99", "xyz")
 	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
-		if e.CurrencyCode != "usd" {
-			t.Errorf("got %v, want usd", e.CurrencyCode)
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
 		}
-		wantError := `invalid currency code "usd"`
+		wantError := `invalid currency code "xyz"`
 		if e.Error() != wantError {
 			t.Errorf("got %v, want %v", e.Error(), wantError)
 		}
 	} else {
 		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
 	}
+	if errors.Is(err, currency.ErrEmptyCurrency) {
+		t.Errorf("got %v, want a non-empty currency code error", err)
+	}
+
+	_, err = currency.NewAmount("10.99", "")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "" {
+			t.Errorf(`got %v, want ""`, e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	if !errors.Is(err, currency.ErrEmptyCurrency) {
+		t.Errorf("got %v, want currency.ErrEmptyCurrency", err)
+	}
 
 	a, err := currency.NewAmount("10.99", "USD")
 	if err != nil {
@@ -51,6 +69,133 @@ func TestNewAmount(t *testing.T) {
 	if a.String() != "10.99 USD" {
 		t.Errorf("got %v, want 10.99 USD", a.String())
 	}
+
+	// Surrounding whitespace is trimmed.
+	for _, n := range []string{" 12.34", "12.34 ", " 12.34 ", "\t12.34\n"} {
+		a, err := currency.NewAmount(n, "USD")
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if a.Number() != "12.34" {
+			t.Errorf("got %v, want 12.34", a.Number())
+		}
+	}
+
+	// Currency codes are case-insensitive.
+	want, _ := currency.NewAmount("10.99", "USD")
+	for _, currencyCode := range []string{"usd", "Usd", "USD"} {
+		a, err := currency.NewAmount("10.99", currencyCode)
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+		if a.CurrencyCode() != "USD" {
+			t.Errorf("got %v, want USD", a.CurrencyCode())
+		}
+		if !a.Equal(want) {
+			t.Errorf("got %v, want %v", a, want)
+		}
+	}
+
+	// Internal whitespace and non-finite numbers are rejected.
+	for _, n := range []string{"1 2.34", "12. 34", "- 12.34", " ", "", "NaN", "Infinity", "-Inf"} {
+		_, err := currency.NewAmount(n, "USD")
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %q, want %q", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	for _, s := range []string{"", "12.99", "USD", " USD", "12.99  USD", "12.99\u00a0USD", "1,234.56 USD", "1.234,56 USD", "$12.99 USD", "12.99 USD extra"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := currency.ParseAmount(s)
+			if _, ok := err.(currency.InvalidNumberError); !ok {
+				t.Errorf("got %T, want currency.InvalidNumberError", err)
+			}
+		})
+	}
+	for _, s := range []string{"12.99 ", "12.99 XYZ", "12.99 US"} {
+		t.Run(s, func(t *testing.T) {
+			_, err := currency.ParseAmount(s)
+			if _, ok := err.(currency.InvalidCurrencyCodeError); !ok {
+				t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+			}
+		})
+	}
+
+	// Round-trip via String().
+	for _, n := range []string{"1234.56", "-0.5", "1500", "0.000001", "12345678901234567890.123"} {
+		t.Run(n, func(t *testing.T) {
+			a, _ := currency.NewAmount(n, "EUR")
+			b, err := currency.ParseAmount(a.String())
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !b.Equal(a) {
+				t.Errorf("got %v, want %v", b, a)
+			}
+			if b.String() != a.String() {
+				t.Errorf("got %v, want %v", b.String(), a.String())
+			}
+		})
+	}
+}
+
+func TestNewAmountFromLocalizedDigits(t *testing.T) {
+	for _, n := range []string{"١٬٢٣٤", "١٢٣٫٤٥", "12a", ""} {
+		_, err := currency.NewAmountFromLocalizedDigits(n, "USD")
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	_, err := currency.NewAmountFromLocalizedDigits("١٢٣", "xyz")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		n    string
+		want string
+	}{
+		// Arabic-Indic digits.
+		{"١٢٣٤.٥٦", "1234.56"},
+		{"-٠.٥", "-0.5"},
+		// Extended Arabic-Indic digits.
+		{"۱۲۳۴.۵۶", "1234.56"},
+		// Devanagari digits.
+		{"१२३४.५६", "1234.56"},
+		{"९९.००", "99.00"},
+		// Bengali digits, mixed with ASCII digits.
+		{"১২3.4৫", "123.45"},
+		{"1234.56", "1234.56"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.n, func(t *testing.T) {
+			a, err := currency.NewAmountFromLocalizedDigits(tt.n, "INR")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if a.Number() != tt.want {
+				t.Errorf("got %v, want %v", a.Number(), tt.want)
+			}
+			if a.CurrencyCode() != "INR" {
+				t.Errorf("got %v, want INR", a.CurrencyCode())
+			}
+		})
+	}
 }
 
 func TestNewAmountFromBigInt(t *testing.T) {
@@ -67,12 +212,12 @@ func TestNewAmountFromBigInt(t *testing.T) {
 		t.Errorf("got %T, want currency.InvalidNumberError", err)
 	}
 
-	_, err = currency.NewAmountFromBigInt(big.NewInt(1099), "usd")
+	_, err = currency.NewAmountFromBigInt(big.NewInt(1099), "xyz")
 	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
-		if e.CurrencyCode != "usd" {
-			t.Errorf("got %v, want usd", e.CurrencyCode)
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
 		}
-		wantError := `invalid currency code "usd"`
+		wantError := `invalid currency code "xyz"`
 		if e.Error() != wantError {
 			t.Errorf("got %v, want %v", e.Error(), wantError)
 		}
@@ -110,12 +255,12 @@ func TestNewAmountFromBigInt(t *testing.T) {
 }
 
 func TestNewAmountFromInt64(t *testing.T) {
-	_, err := currency.NewAmountFromInt64(1099, "usd")
+	_, err := currency.NewAmountFromInt64(1099, "xyz")
 	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
-		if e.CurrencyCode != "usd" {
-			t.Errorf("got %v, want usd", e.CurrencyCode)
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
 		}
-		wantError := `invalid currency code "usd"`
+		wantError := `invalid currency code "xyz"`
 		if e.Error() != wantError {
 			t.Errorf("got %v, want %v", e.Error(), wantError)
 		}
@@ -149,6 +294,181 @@ func TestNewAmountFromInt64(t *testing.T) {
 	}
 }
 
+func TestNewAmountFromMinorString(t *testing.T) {
+	for _, n := range []string{"12.34", "1234.", "1E2", "12 34", "", "INVALID", "NaN"} {
+		_, err := currency.NewAmountFromMinorString(n, "USD")
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	_, err := currency.NewAmountFromMinorString("1099", "xyz")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		n            string
+		currencyCode string
+		wantNumber   string
+	}{
+		{"1234", "USD", "12.34"},
+		{"5", "USD", "0.05"},
+		{"-1234", "USD", "-12.34"},
+		{"1000", "JPY", "1000"},
+		{"1234", "OMR", "1.234"},
+		{"123456789012345678901234567890", "USD", "1234567890123456789012345678.90"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, err := currency.NewAmountFromMinorString(tt.n, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+			}
+			if a.Number() != tt.wantNumber {
+				t.Errorf("got %v, want %v", a.Number(), tt.wantNumber)
+			}
+			if a.CurrencyCode() != tt.currencyCode {
+				t.Errorf("got %v, want %v", a.CurrencyCode(), tt.currencyCode)
+			}
+		})
+	}
+}
+
+func TestAmount_Copy(t *testing.T) {
+	tests := []string{
+		"20.99",
+		"-3.50",
+		// A number too large to be stored inline by the underlying decimal.
+		"4000000000000000000000000000000000000.01",
+	}
+
+	for _, number := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(number, "USD")
+			b := a.Copy()
+			if !b.Equal(a) {
+				t.Errorf("got %v, want %v", b, a)
+			}
+			b, _ = b.Add(a)
+			b, _ = b.Mul("3")
+			b = b.Round()
+			if a.Number() != number {
+				t.Errorf("got %v, want %v", a.Number(), number)
+			}
+			if b.Equal(a) {
+				t.Errorf("expected %v to differ from %v", b, a)
+			}
+		})
+	}
+}
+
+func TestAmount_Format(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"1234.59", "USD", "en-US", "$1,234.59"},
+		{"1234.59", "USD", "de-DE", "1.234,59\u00a0$"},
+		{"-1234.5", "EUR", "en-US", "-€1,234.50"},
+		{"-1234.5", "EUR", "de-DE", "-1.234,50\u00a0€"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			got := a.Format(locale)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			want := currency.NewFormatter(locale).Format(a)
+			if got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestAmount_StringWithDigits(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         string
+	}{
+		{"5", "USD", "5.00 USD"},
+		{"5.5", "USD", "5.50 USD"},
+		{"5.55", "USD", "5.55 USD"},
+		{"-0.5", "USD", "-0.50 USD"},
+		// Extra digits are kept.
+		{"5.555", "USD", "5.555 USD"},
+		{"5", "JPY", "5 JPY"},
+		{"1E+3", "JPY", "1000 JPY"},
+		{"5", "KWD", "5.000 KWD"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := a.StringWithDigits()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that the scale matches the currency's digits.
+			digits, _ := currency.GetDigits(tt.currencyCode)
+			b, _ := currency.ParseAmount(got)
+			if scale, _ := currency.CommonScale(b); scale < digits {
+				t.Errorf("got scale %v, want at least %v", scale, digits)
+			}
+		})
+	}
+}
+
+func TestAmount_MajorMinor(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		wantMajor    string
+		wantMinor    string
+	}{
+		{"12.5", "USD", "12", "50"},
+		{"12", "USD", "12", "00"},
+		{"12.345", "USD", "12", "345"},
+		{"-12.5", "USD", "-12", "50"},
+		{"-0.5", "USD", "-0", "50"},
+		{"1000", "JPY", "1000", ""},
+		{"1000.5", "JPY", "1000", "5"},
+		{"1.2", "KWD", "1", "200"},
+		{"1E+3", "USD", "1000", "00"},
+		{"12345678901234567890.1", "USD", "12345678901234567890", "10"},
+		{"99999999999999999999999999999999999999", "USD", "99999999999999999999999999999999999999", "00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			major, minor := a.MajorMinor()
+			if major != tt.wantMajor {
+				t.Errorf("got %v, want %v", major, tt.wantMajor)
+			}
+			if minor != tt.wantMinor {
+				t.Errorf("got %v, want %v", minor, tt.wantMinor)
+			}
+		})
+	}
+}
+
 func TestAmount_BigInt(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -178,6 +498,155 @@ func TestAmount_BigInt(t *testing.T) {
 	}
 }
 
+func TestAmount_ISO20022(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         string
+	}{
+		{"1234.56", "EUR", "1234.56"},
+		{"1234.50", "EUR", "1234.5"},
+		{"1234.00", "EUR", "1234"},
+		{"1234", "USD", "1234"},
+		{"1000", "JPY", "1000"},
+		{"0.00", "USD", "0"},
+		{"0.001", "KWD", "0.001"},
+		{"5.000", "USD", "5"},
+		{"123456789012345678", "JPY", "123456789012345678"},
+		{"1234567890123456.78", "USD", "1234567890123456.78"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got, err := a.ISO20022()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	a, _ := currency.NewAmount("-1.00", "USD")
+	_, err := a.ISO20022()
+	if _, ok := err.(currency.NegativeAmountError); !ok {
+		t.Errorf("got %T, want currency.NegativeAmountError", err)
+	}
+
+	a, _ = currency.NewAmount("1.005", "USD")
+	_, err = a.ISO20022()
+	if e, ok := err.(currency.PrecisionError); ok {
+		if e.Digits != 2 {
+			t.Errorf("got %v, want 2", e.Digits)
+		}
+	} else {
+		t.Errorf("got %T, want currency.PrecisionError", err)
+	}
+
+	for _, n := range []string{"1234567890123456789", "12345678901234567.89"} {
+		a, _ = currency.NewAmount(n, "USD")
+		_, err = a.ISO20022()
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != n {
+				t.Errorf("got %v, want %v", e.Number, n)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	_, err = currency.Amount{}.ISO20022()
+	if _, ok := err.(currency.InvalidCurrencyCodeError); !ok {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+}
+
+func TestAmount_ToFixedPoint(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		scale        uint8
+		want         string
+	}{
+		{"20.99", "USD", 8, "2099000000"},
+		{"-20.99", "USD", 8, "-2099000000"},
+		{"1500", "JPY", 8, "150000000000"},
+		{"1.000000015", "USD", 8, "100000002"},
+		{"12.3564", "USD", 2, "1236"},
+		{"12.3564", "USD", 0, "12"},
+		{"0", "USD", 8, "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got, err := a.ToFixedPoint(tt.scale)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestNewAmountFromFixedPoint(t *testing.T) {
+	_, err := currency.NewAmountFromFixedPoint(nil, 8, "USD")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "nil" {
+			t.Errorf("got %v, want nil", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	_, err = currency.NewAmountFromFixedPoint(big.NewInt(100), 8, "INVALID")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	a, err := currency.NewAmountFromFixedPoint(big.NewInt(1234500000), 8, "USD")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a.Number() != "12.34500000" {
+		t.Errorf("got %v, want 12.34500000", a.Number())
+	}
+
+	// Round-trip at scale 8.
+	for _, tt := range []struct{ number, currencyCode string }{
+		{"20.99", "USD"},
+		{"-0.01", "USD"},
+		{"1500", "JPY"},
+		{"-98765", "JPY"},
+	} {
+		t.Run(tt.number+tt.currencyCode, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			v, err := a.ToFixedPoint(8)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			b, err := currency.NewAmountFromFixedPoint(v, 8, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !b.Equal(a) {
+				t.Errorf("got %v, want %v", b, a)
+			}
+		})
+	}
+}
+
 func TestAmount_Int64(t *testing.T) {
 	// Number that can't be represented as an int64.
 	a, _ := currency.NewAmount("922337203685477598799", "USD")
@@ -217,15 +686,114 @@ func TestAmount_Int64(t *testing.T) {
 	}
 }
 
-func TestAmount_Convert(t *testing.T) {
-	a, _ := currency.NewAmount("20.99", "USD")
+func TestAmount_ToStored(t *testing.T) {
+	// Number that can't be represented as an int64.
+	a, _ := currency.NewAmount("922337203685477598799", "USD")
+	_, err := a.ToStored()
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "922337203685477598799" {
+			t.Errorf("got %v, want 922337203685477598799", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         currency.StoredAmount
+	}{
+		{"20.99", "USD", currency.StoredAmount{2099, 2, "USD"}},
+		{"-20.99", "USD", currency.StoredAmount{-2099, 2, "USD"}},
+		{"50", "USD", currency.StoredAmount{50, 0, "USD"}},
+		{"50.000", "JPY", currency.StoredAmount{50000, 3, "JPY"}},
+		{"0", "EUR", currency.StoredAmount{0, 0, "EUR"}},
+		{"12.3456789012", "USD", currency.StoredAmount{123456789012, 10, "USD"}},
+		{"-0.000000000000000001", "USD", currency.StoredAmount{-1, 18, "USD"}},
+		{"1.2E+3", "USD", currency.StoredAmount{1200, 0, "USD"}},
+		{"9223372036854775807", "USD", currency.StoredAmount{9223372036854775807, 0, "USD"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got, err := a.ToStored()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that the conversion round-trips.
+			b, err := got.ToAmount()
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !b.Equal(a) {
+				t.Errorf("got %v, want %v", b, a)
+			}
+		})
+	}
+}
 
-	_, err := a.Convert("eur", "0.91")
+func TestStoredAmount_ToAmount(t *testing.T) {
+	_, err := currency.StoredAmount{2099, 2, "xyz"}.ToAmount()
 	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
-		if e.CurrencyCode != "eur" {
-			t.Errorf("got %v, want eur", e.CurrencyCode)
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	_, err = currency.StoredAmount{2099, 2, ""}.ToAmount()
+	if _, ok := err.(currency.InvalidCurrencyCodeError); !ok {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	a, err := currency.StoredAmount{-2099, 3, "USD"}.ToAmount()
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if a.String() != "-2.099 USD" {
+		t.Errorf("got %v, want -2.099 USD", a.String())
+	}
+}
+
+func TestAmount_WithCurrency(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+	for _, currencyCode := range []string{"xyz", "XXX", ""} {
+		_, err := a.WithCurrency(currencyCode)
+		if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+			if e.CurrencyCode != currencyCode {
+				t.Errorf("got %v, want %v", e.CurrencyCode, currencyCode)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
 		}
-		wantError := `invalid currency code "eur"`
+	}
+
+	b, err := a.WithCurrency("EUR")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "20.99 EUR" {
+		t.Errorf("got %v, want 20.99 EUR", b.String())
+	}
+	// Confirm that a is unchanged.
+	if a.String() != "20.99 USD" {
+		t.Errorf("got %v, want 20.99 USD", a.String())
+	}
+}
+
+func TestAmount_Convert(t *testing.T) {
+	a, _ := currency.NewAmount("20.99", "USD")
+
+	_, err := a.Convert("xyz", "0.91")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+		wantError := `invalid currency code "xyz"`
 		if e.Error() != wantError {
 			t.Errorf("got %v, want %v", e.Error(), wantError)
 		}
@@ -439,6 +1007,444 @@ func TestAmount_Div(t *testing.T) {
 	}
 }
 
+func TestAmount_MulBigInt(t *testing.T) {
+	a, _ := currency.NewAmount("99.99", "USD")
+	_, err := a.MulBigInt(nil)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "nil" {
+			t.Errorf("got %v, want nil", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	// A quantity exceeding math.MaxInt64.
+	n, _ := new(big.Int).SetString("100000000000000000000", 10)
+	tests := []struct {
+		number string
+		n      *big.Int
+		want   string
+	}{
+		{"99.99", big.NewInt(3), "299.97"},
+		{"99.99", big.NewInt(-3), "-299.97"},
+		{"99.99", big.NewInt(0), "0.00"},
+		{"12.34", n, "1234000000000000000000.00"},
+		{"-12.34", n, "-1234000000000000000000.00"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.MulBigInt(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.String() != tt.want+" USD" {
+				t.Errorf("got %v, want %v USD", b.String(), tt.want)
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+	// Confirm that n is unchanged.
+	if n.String() != "100000000000000000000" {
+		t.Errorf("got %v, want 100000000000000000000", n.String())
+	}
+}
+
+func TestAmount_DivInt(t *testing.T) {
+	a, _ := currency.NewAmount("99.99", "USD")
+	_, err := a.DivInt(0)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "0" {
+			t.Errorf("got %v, want 0", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number string
+		n      int64
+		want   string
+	}{
+		{"99.99", 3, "33.33"},
+		{"100.00", 4, "25"},
+		{"10", 3, "3.333333333333333333"},
+		{"-10", 4, "-2.5"},
+		{"10", -4, "-2.5"},
+		{"9223372036854775807", 1, "9223372036854775807"},
+		{"12345678901234567890", 10, "1234567890123456789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.DivInt(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestAmount_Rounded(t *testing.T) {
+	a, _ := currency.NewAmount("10.00", "USD")
+	b, _ := currency.NewAmount("3.33", "EUR")
+	if _, err := a.AddRounded(b); err == nil {
+		t.Errorf("expected error for mismatched currencies")
+	}
+	if _, err := a.SubRounded(b); err == nil {
+		t.Errorf("expected error for mismatched currencies")
+	}
+	if _, err := a.MulRounded("INVALID"); err == nil {
+		t.Errorf("expected error for an invalid number")
+	}
+
+	// Two items at 10.00 USD, each with 8.25% tax.
+	rate := "1.0825"
+	// Full precision: 10.825 + 10.825 = 21.65.
+	x, _ := a.Mul(rate)
+	y, _ := a.Mul(rate)
+	total, _ := x.Add(y)
+	total = total.Round()
+	if total.Number() != "21.65" {
+		t.Errorf("got %v, want 21.65", total.Number())
+	}
+	// Step-wise: 10.83 + 10.83 = 21.66.
+	x, _ = a.MulRounded(rate)
+	y, _ = a.MulRounded(rate)
+	if x.Number() != "10.83" {
+		t.Errorf("got %v, want 10.83", x.Number())
+	}
+	total, _ = x.AddRounded(y)
+	if total.Number() != "21.66" {
+		t.Errorf("got %v, want 21.66", total.Number())
+	}
+	total, _ = total.SubRounded(x)
+	if total.Number() != "10.83" {
+		t.Errorf("got %v, want 10.83", total.Number())
+	}
+
+	// Results with more digits than the currency are rounded.
+	c, _ := currency.NewAmount("1.005", "USD")
+	d, _ := currency.NewAmount("1.001", "USD")
+	sum, _ := c.AddRounded(d)
+	if sum.Number() != "2.01" {
+		t.Errorf("got %v, want 2.01", sum.Number())
+	}
+	diff, _ := c.SubRounded(d)
+	if diff.Number() != "0.00" {
+		t.Errorf("got %v, want 0.00", diff.Number())
+	}
+}
+
+func TestAmount_MulRound(t *testing.T) {
+	a, _ := currency.NewAmount("9.99", "USD")
+	_, err := a.MulRound("INVALID", 2, currency.RoundHalfUp)
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		n      string
+		digits uint8
+		mode   currency.RoundingMode
+		want   string
+	}{
+		{"3", 2, currency.RoundHalfUp, "29.97"},
+		{"0.333", 2, currency.RoundHalfUp, "3.33"},
+		{"0.333", 2, currency.RoundDown, "3.32"},
+		{"0.333", 0, currency.RoundHalfUp, "3"},
+		{"1.0825", currency.DefaultDigits, currency.RoundHalfEven, "10.81"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			b, err := a.MulRound(tt.n, tt.digits, tt.mode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+		})
+	}
+	// Confirm that a is unchanged.
+	if a.Number() != "9.99" {
+		t.Errorf("got %v, want 9.99", a.Number())
+	}
+}
+
+func TestAmount_AddPercent(t *testing.T) {
+	a, _ := currency.NewAmount("100", "USD")
+	_, err := a.AddPercent("INVALID")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number string
+		p      string
+		want   string
+	}{
+		{"100", "20", "120"},
+		{"100.00", "20", "120.00"},
+		{"100", "8.25", "108.25"},
+		{"19.99", "8.25", "21.639175"},
+		{"100", "-10", "90"},
+		{"100", "0", "100"},
+		{"-50.00", "10", "-55.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.AddPercent(tt.p)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestAmount_SubtractPercent(t *testing.T) {
+	a, _ := currency.NewAmount("100", "USD")
+	_, err := a.SubtractPercent("INVALID")
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	tests := []struct {
+		number string
+		p      string
+		want   string
+	}{
+		{"100", "10", "90"},
+		{"100.00", "10", "90.00"},
+		{"100", "8.25", "91.75"},
+		{"19.99", "8.25", "18.340825"},
+		{"100", "-20", "120"},
+		{"100", "100", "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b, err := a.SubtractPercent(tt.p)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+			if b.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", b.CurrencyCode())
+			}
+		})
+	}
+}
+
+func TestAmount_AllocateWithRemainder(t *testing.T) {
+	a, _ := currency.NewAmount("10.00", "USD")
+	for _, n := range []int{0, -1} {
+		_, _, err := a.AllocateWithRemainder(n)
+		if _, ok := err.(currency.InvalidNumberError); !ok {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number         string
+		currencyCode   string
+		n              int
+		wantShares     []string
+		wantExtraUnits int
+	}{
+		{"10.00", "USD", 3, []string{"3.34", "3.33", "3.33"}, 1},
+		{"10", "USD", 3, []string{"3.34", "3.33", "3.33"}, 1},
+		{"10.01", "USD", 3, []string{"3.34", "3.34", "3.33"}, 2},
+		{"10.00", "USD", 2, []string{"5.00", "5.00"}, 0},
+		{"-10.00", "USD", 3, []string{"-3.34", "-3.33", "-3.33"}, 1},
+		{"0.02", "USD", 3, []string{"0.01", "0.01", "0.00"}, 2},
+		{"-0.01", "USD", 2, []string{"-0.01", "0.00"}, 1},
+		{"1000", "JPY", 3, []string{"334", "333", "333"}, 1},
+		{"10.000", "KWD", 3, []string{"3.334", "3.333", "3.333"}, 1},
+		// Amounts more precise than their currency keep their precision.
+		{"10.0001", "USD", 2, []string{"5.0001", "5.0000"}, 1},
+		// Numbers too large for the default precision.
+		{"1E+30", "USD", 3, []string{"333333333333333333333333333333.34", "333333333333333333333333333333.33", "333333333333333333333333333333.33"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			shares, extraUnits, err := a.AllocateWithRemainder(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if extraUnits != tt.wantExtraUnits {
+				t.Errorf("got %v, want %v", extraUnits, tt.wantExtraUnits)
+			}
+			if len(shares) != len(tt.wantShares) {
+				t.Fatalf("got %v shares, want %v", len(shares), len(tt.wantShares))
+			}
+			sum, _ := currency.NewAmount("0", tt.currencyCode)
+			for i, share := range shares {
+				if share.Number() != tt.wantShares[i] {
+					t.Errorf("share %v: got %v, want %v", i, share.Number(), tt.wantShares[i])
+				}
+				if share.CurrencyCode() != tt.currencyCode {
+					t.Errorf("share %v: got %v, want %v", i, share.CurrencyCode(), tt.currencyCode)
+				}
+				sum, _ = sum.Add(share)
+			}
+			if !sum.Equal(a) {
+				t.Errorf("got sum %v, want %v", sum, a)
+			}
+		})
+	}
+}
+
+func TestAmount_SplitBalanced(t *testing.T) {
+	a, _ := currency.NewAmount("10.00", "USD")
+	for _, n := range []int{0, -1} {
+		_, err := a.SplitBalanced(n)
+		if _, ok := err.(currency.InvalidNumberError); !ok {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number     string
+		n          int
+		wantShares []string
+	}{
+		// A single extra unit goes to the first share.
+		{"120.01", 12, []string{"10.01", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00"}},
+		// Extra units are spaced apart.
+		{"99.99", 12, []string{"8.34", "8.33", "8.33", "8.33", "8.34", "8.33", "8.33", "8.33", "8.34", "8.33", "8.33", "8.33"}},
+		{"100.02", 12, []string{"8.34", "8.33", "8.34", "8.33", "8.34", "8.33", "8.34", "8.33", "8.34", "8.33", "8.34", "8.33"}},
+		{"100.06", 12, []string{"8.34", "8.33", "8.34", "8.34", "8.34", "8.34", "8.34", "8.33", "8.34", "8.34", "8.34", "8.34"}},
+		{"-99.99", 12, []string{"-8.34", "-8.33", "-8.33", "-8.33", "-8.34", "-8.33", "-8.33", "-8.33", "-8.34", "-8.33", "-8.33", "-8.33"}},
+		{"120.00", 12, []string{"10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00", "10.00"}},
+		{"10.00", 3, []string{"3.34", "3.33", "3.33"}},
+		{"1E+30", 3, []string{"333333333333333333333333333333.34", "333333333333333333333333333333.33", "333333333333333333333333333333.33"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			shares, err := a.SplitBalanced(tt.n)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(shares) != len(tt.wantShares) {
+				t.Fatalf("got %v shares, want %v", len(shares), len(tt.wantShares))
+			}
+			sum, _ := currency.NewAmount("0", "USD")
+			for i, share := range shares {
+				if share.Number() != tt.wantShares[i] {
+					t.Errorf("share %v: got %v, want %v", i, share.Number(), tt.wantShares[i])
+				}
+				sum, _ = sum.Add(share)
+			}
+			if !sum.Equal(a) {
+				t.Errorf("got sum %v, want %v", sum, a)
+			}
+		})
+	}
+}
+
+func TestAmount_SplitByDenomination(t *testing.T) {
+	a, _ := currency.NewAmount("85", "USD")
+	x, _ := currency.NewAmount("20", "EUR")
+	_, _, err := a.SplitByDenomination(x)
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	for _, number := range []string{"0", "-20"} {
+		d, _ := currency.NewAmount(number, "USD")
+		_, _, err := a.SplitByDenomination(d)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != number {
+				t.Errorf("got %v, want %v", e.Number, number)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+
+	tests := []struct {
+		number        string
+		denomination  string
+		wantCount     int
+		wantRemainder string
+	}{
+		{"85", "20", 4, "5"},
+		{"85.00", "20", 4, "5.00"},
+		{"80", "20", 4, "0"},
+		{"19.99", "20", 0, "19.99"},
+		{"3.75", "0.25", 15, "0.00"},
+		{"3.80", "0.25", 15, "0.05"},
+		{"-85", "20", -4, "-5"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			d, _ := currency.NewAmount(tt.denomination, "USD")
+			count, remainder, err := a.SplitByDenomination(d)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if count != tt.wantCount {
+				t.Errorf("got %v, want %v", count, tt.wantCount)
+			}
+			if remainder.Number() != tt.wantRemainder {
+				t.Errorf("got %v, want %v", remainder.Number(), tt.wantRemainder)
+			}
+			if remainder.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", remainder.CurrencyCode())
+			}
+		})
+	}
+}
+
 func TestAmount_Round(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -487,11 +1493,18 @@ func TestAmount_RoundTo(t *testing.T) {
 		{"12.345", 2, currency.RoundDown, "12.34"},
 		{"12.347", 2, currency.RoundDown, "12.34"},
 
+		{"12.343", 2, currency.RoundHalfEven, "12.34"},
+		{"12.345", 2, currency.RoundHalfEven, "12.34"},
+		{"12.355", 2, currency.RoundHalfEven, "12.36"},
+		{"12.3451", 2, currency.RoundHalfEven, "12.35"},
+		{"12.347", 2, currency.RoundHalfEven, "12.35"},
+
 		// Negative amounts.
 		{"-12.345", 2, currency.RoundHalfUp, "-12.35"},
 		{"-12.345", 2, currency.RoundHalfDown, "-12.34"},
 		{"-12.345", 2, currency.RoundUp, "-12.35"},
 		{"-12.345", 2, currency.RoundDown, "-12.34"},
+		{"-12.345", 2, currency.RoundHalfEven, "-12.34"},
 
 		// More digits that the amount has.
 		{"12.345", 4, currency.RoundHalfUp, "12.3450"},
@@ -531,6 +1544,126 @@ func TestAmount_RoundTo(t *testing.T) {
 	}
 }
 
+func TestAmount_RoundBankers(t *testing.T) {
+	tests := []struct {
+		number string
+		digits uint8
+		want   string
+	}{
+		{"0.125", 2, "0.12"},
+		{"0.135", 2, "0.14"},
+		{"0.1250", 2, "0.12"},
+		{"0.12500001", 2, "0.13"},
+		{"0.124999", 2, "0.12"},
+		{"-0.125", 2, "-0.12"},
+		{"-0.135", 2, "-0.14"},
+		{"2.5", 0, "2"},
+		{"3.5", 0, "4"},
+		{"-2.5", 0, "-2"},
+		{"-3.5", 0, "-4"},
+		{"0.5", 0, "0"},
+		{"12345678901234567890.0345", 3, "12345678901234567890.034"},
+		{"12345678901234567890.0355", 3, "12345678901234567890.036"},
+		// DefaultDigits uses the currency's digits.
+		{"0.125", currency.DefaultDigits, "0.12"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			b := a.RoundBankers(tt.digits)
+			if b.Number() != tt.want {
+				t.Errorf("got %v, want %v", b.Number(), tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_CeilFloor(t *testing.T) {
+	tests := []struct {
+		number    string
+		wantCeil  string
+		wantFloor string
+	}{
+		{"12.01", "13", "12"},
+		{"12.99", "13", "12"},
+		{"12.00", "12", "12"},
+		{"12", "12", "12"},
+		{"0.01", "1", "0"},
+		{"0", "0", "0"},
+		{"-0.01", "0", "-1"},
+		{"-12.01", "-12", "-13"},
+		{"-12.99", "-12", "-13"},
+		{"-12.00", "-12", "-12"},
+		{"99.5", "100", "99"},
+		{"12345678901234567890.0001", "12345678901234567891", "12345678901234567890"},
+		{"-999999999999999999999999999999999999999999.5", "-999999999999999999999999999999999999999999", "-1000000000000000000000000000000000000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			ceil := a.Ceil()
+			if ceil.Number() != tt.wantCeil {
+				t.Errorf("got %v, want %v", ceil.Number(), tt.wantCeil)
+			}
+			if ceil.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", ceil.CurrencyCode())
+			}
+			floor := a.Floor()
+			if floor.Number() != tt.wantFloor {
+				t.Errorf("got %v, want %v", floor.Number(), tt.wantFloor)
+			}
+			if floor.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", floor.CurrencyCode())
+			}
+			// Confirm that a is unchanged.
+			if a.Number() != tt.number {
+				t.Errorf("got %v, want %v", a.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestAmount_WouldRound(t *testing.T) {
+	tests := []struct {
+		number string
+		digits uint8
+		want   bool
+	}{
+		{"1.005", 2, true},
+		{"1.00", 2, false},
+		{"1", 2, false},
+		{"1.5", 0, true},
+		{"1200", 0, false},
+		{"-1.005", 2, true},
+		{"0.001", 2, true},
+		// Trailing zeroes past the cutoff are not a rounding loss.
+		{"1.0000", 2, false},
+		{"1.0100", 2, false},
+		{"1.0050", 3, false},
+		{"1.0050", 2, true},
+		// DefaultDigits uses the currency's digits.
+		{"1.005", currency.DefaultDigits, true},
+		{"1.050", currency.DefaultDigits, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got := a.WouldRound(tt.digits)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			// Confirm that WouldRound matches the RoundTo result.
+			b := a.RoundTo(tt.digits, currency.RoundHalfUp)
+			if changed, _ := a.Cmp(b); (changed != 0) != tt.want {
+				t.Errorf("got %v from RoundTo, want WouldRound %v", b, tt.want)
+			}
+		})
+	}
+}
+
 func TestAmount_Cmp(t *testing.T) {
 	a, _ := currency.NewAmount("3.33", "USD")
 	b, _ := currency.NewAmount("3.33", "EUR")
@@ -551,25 +1684,79 @@ func TestAmount_Cmp(t *testing.T) {
 	}
 
 	tests := []struct {
-		aNumber string
-		bNumber string
-		want    int
+		aNumber string
+		bNumber string
+		want    int
+	}{
+		{"3.33", "6.66", -1},
+		{"3.33", "3.33", 0},
+		{"6.66", "3.33", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.aNumber, "USD")
+			b, _ := currency.NewAmount(tt.bNumber, "USD")
+			got, err := a.Cmp(b)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_Compare(t *testing.T) {
+	a, _ := currency.NewAmount("3.33", "USD")
+	b, _ := currency.NewAmount("3.33", "EUR")
+	_, err := a.Compare(b)
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != b {
+			t.Errorf("got %v, want %v", e.B, b)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	tests := []struct {
+		aNumber   string
+		bNumber   string
+		wantSign  int
+		wantDiff  string
+		wantEqual bool
 	}{
-		{"3.33", "6.66", -1},
-		{"3.33", "3.33", 0},
-		{"6.66", "3.33", 1},
+		{"3.33", "5.00", -1, "1.67", false},
+		{"5.00", "3.33", 1, "1.67", false},
+		{"3.33", "3.33", 0, "0.00", true},
+		{"3.330", "3.33", 0, "0.000", true},
+		{"-1.50", "1.50", -1, "3.00", false},
+		{"12345678901234567890.12", "0.01", 1, "12345678901234567890.11", false},
 	}
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			a, _ := currency.NewAmount(tt.aNumber, "USD")
 			b, _ := currency.NewAmount(tt.bNumber, "USD")
-			got, err := a.Cmp(b)
+			got, err := a.Compare(b)
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
-			if got != tt.want {
-				t.Errorf("got %v, want %v", got, tt.want)
+			if got.Sign != tt.wantSign {
+				t.Errorf("got %v, want %v", got.Sign, tt.wantSign)
+			}
+			if got.Diff.Number() != tt.wantDiff {
+				t.Errorf("got %v, want %v", got.Diff.Number(), tt.wantDiff)
+			}
+			if got.Diff.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", got.Diff.CurrencyCode())
+			}
+			if got.Equal != tt.wantEqual {
+				t.Errorf("got %v, want %v", got.Equal, tt.wantEqual)
 			}
 		})
 	}
@@ -632,6 +1819,453 @@ func TestAmount_Checks(t *testing.T) {
 	}
 }
 
+func TestAmount_OrderOfMagnitude(t *testing.T) {
+	tests := []struct {
+		number string
+		want   int
+	}{
+		{"1234.56", 3},
+		{"1000", 3},
+		{"999.99", 2},
+		{"10", 1},
+		{"9", 0},
+		{"1.00", 0},
+		{"0.5", -1},
+		{"0.05", -2},
+		{"0.050", -2},
+		{"0.001", -3},
+		{"-1234.56", 3},
+		{"-0.05", -2},
+		{"1E+40", 40},
+		{"12345678901234567890", 19},
+		{"0", math.MinInt32},
+		{"-0.00", math.MinInt32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got := a.OrderOfMagnitude()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_IsInteger(t *testing.T) {
+	tests := []struct {
+		number string
+		want   bool
+	}{
+		{"5", true},
+		{"5.00", true},
+		{"5.000", true},
+		{"5.50", false},
+		{"5.001", false},
+		{"-12.00", true},
+		{"-12.01", false},
+		{"0", true},
+		{"0.00", true},
+		{"1200", true},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			got := a.IsInteger()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_IsWhole(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         bool
+	}{
+		{"1000", "JPY", true},
+		{"1000.5", "JPY", true},
+		{"12.00", "USD", true},
+		{"12", "USD", true},
+		{"12.50", "USD", false},
+		{"12.01", "USD", false},
+		// Digits past the currency's digits are rounded first.
+		{"12.001", "USD", true},
+		{"11.999", "USD", true},
+		{"-12.00", "USD", true},
+		{"-12.50", "USD", false},
+		{"1.000", "KWD", true},
+		{"1.001", "KWD", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number+" "+tt.currencyCode, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := a.IsWhole()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAmount_FitsCurrencyPrecision(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         bool
+		wantDigits   uint8
+	}{
+		{"12.34", "USD", true, 2},
+		{"12.340", "USD", true, 2},
+		{"12.345", "USD", false, 2},
+		{"-0.001", "USD", false, 2},
+		{"1000", "JPY", true, 0},
+		{"1000.5", "JPY", false, 0},
+		{"1.234", "KWD", true, 3},
+		{"1.2345", "KWD", false, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number+" "+tt.currencyCode, func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := a.FitsCurrencyPrecision()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			err := a.AssertCurrencyPrecision()
+			if tt.want {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if e, ok := err.(currency.PrecisionError); ok {
+				if e.Amount != a {
+					t.Errorf("got %v, want %v", e.Amount, a)
+				}
+				if e.Digits != tt.wantDigits {
+					t.Errorf("got %v, want %v", e.Digits, tt.wantDigits)
+				}
+			} else {
+				t.Errorf("got %T, want currency.PrecisionError", err)
+			}
+		})
+	}
+}
+
+func TestAmount_ZeroValue(t *testing.T) {
+	var a currency.Amount
+	if a.Number() != "0" {
+		t.Errorf("got %v, want 0", a.Number())
+	}
+	if a.CurrencyCode() != "" {
+		t.Errorf(`got %v, want ""`, a.CurrencyCode())
+	}
+	if !a.IsZero() {
+		t.Errorf("got %v, want true", a.IsZero())
+	}
+	b, _ := currency.NewAmount("10.99", "USD")
+	if _, err := a.Add(b); err == nil {
+		t.Errorf("expected error for mismatched currencies")
+	}
+	if _, err := b.Sub(a); err == nil {
+		t.Errorf("expected error for mismatched currencies")
+	}
+	if err := a.ValidateAsPrice(); !errors.Is(err, currency.ErrEmptyCurrency) {
+		t.Errorf("got %v, want currency.ErrEmptyCurrency", err)
+	}
+	// Encoded zero values can't be decoded.
+	data, _ := json.Marshal(a)
+	var c currency.Amount
+	if err := json.Unmarshal(data, &c); !errors.Is(err, currency.ErrEmptyCurrency) {
+		t.Errorf("got %v, want currency.ErrEmptyCurrency", err)
+	}
+}
+
+func TestAmount_ValidateAsPrice(t *testing.T) {
+	var zero currency.Amount
+	err := zero.ValidateAsPrice()
+	if _, ok := err.(currency.InvalidCurrencyCodeError); !ok {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	a, _ := currency.NewAmount("-0.01", "USD")
+	err = a.ValidateAsPrice()
+	if e, ok := err.(currency.NegativeAmountError); ok {
+		if e.Amount != a {
+			t.Errorf("got %v, want %v", e.Amount, a)
+		}
+		wantError := `amount "-0.01 USD" is negative`
+		if e.Error() != wantError {
+			t.Errorf("got %v, want %v", e.Error(), wantError)
+		}
+	} else {
+		t.Errorf("got %T, want currency.NegativeAmountError", err)
+	}
+	if _, ok := a.ValidateAsPriceStrict().(currency.NegativeAmountError); !ok {
+		t.Errorf("got %T, want currency.NegativeAmountError", a.ValidateAsPriceStrict())
+	}
+
+	b, _ := currency.NewAmount("0.005", "USD")
+	if err := b.ValidateAsPrice(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err = b.ValidateAsPriceStrict()
+	if e, ok := err.(currency.PrecisionError); ok {
+		if e.Amount != b {
+			t.Errorf("got %v, want %v", e.Amount, b)
+		}
+		if e.Digits != 2 {
+			t.Errorf("got %v, want 2", e.Digits)
+		}
+		wantError := `amount "0.005 USD" has more than 2 fraction digits`
+		if e.Error() != wantError {
+			t.Errorf("got %v, want %v", e.Error(), wantError)
+		}
+	} else {
+		t.Errorf("got %T, want currency.PrecisionError", err)
+	}
+
+	for _, n := range []string{"0", "9.99", "5.000", "1234567890.12"} {
+		c, _ := currency.NewAmount(n, "USD")
+		if err := c.ValidateAsPrice(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if err := c.ValidateAsPriceStrict(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+}
+
+func TestAmount_ValueSemantics(t *testing.T) {
+	tests := []struct {
+		aNumber string
+		bNumber string
+	}{
+		{"20.99", "3.50"},
+		{"-20.995", "0.005"},
+		// Numbers too large to be stored inline by the underlying decimal.
+		{"4000000000000000000000000000000000000.01", "1.01"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.aNumber, "USD")
+			b, _ := currency.NewAmount(tt.bNumber, "USD")
+			operations := map[string]func(){
+				"Convert": func() { a.Convert("EUR", "0.91") },
+				"Add":     func() { a.Add(b) },
+				"Sub":     func() { a.Sub(b) },
+				"Mul":     func() { a.Mul("3") },
+				"Div":     func() { a.Div("3") },
+				"Round":   func() { a.Round() },
+				"RoundTo": func() { a.RoundTo(0, currency.RoundUp) },
+				"BigInt":  func() { a.BigInt() },
+				"Int64":   func() { a.Int64() },
+				"Cmp":     func() { a.Cmp(b) },
+				"Equal":   func() { a.Equal(b) },
+			}
+			for name, operation := range operations {
+				operation()
+				if a.Number() != tt.aNumber {
+					t.Errorf("%v: got %v, want %v", name, a.Number(), tt.aNumber)
+				}
+				if b.Number() != tt.bNumber {
+					t.Errorf("%v: got %v, want %v", name, b.Number(), tt.bNumber)
+				}
+			}
+
+			// Results must not share state with a or b.
+			c, _ := a.Add(b)
+			d, _ := c.Mul("2")
+			c, _ = c.Sub(b)
+			if a.Number() != tt.aNumber {
+				t.Errorf("got %v, want %v", a.Number(), tt.aNumber)
+			}
+			if !c.Equal(a) {
+				t.Errorf("got %v, want %v", c, a)
+			}
+			e, _ := a.Add(b)
+			e, _ = e.Mul("2")
+			if !d.Equal(e) {
+				t.Errorf("got %v, want %v", d, e)
+			}
+		})
+	}
+}
+
+func TestCommonScale(t *testing.T) {
+	_, err := currency.CommonScale()
+	if err != currency.ErrNoAmounts {
+		t.Errorf("got %v, want currency.ErrNoAmounts", err)
+	}
+	a, _ := currency.NewAmount("3.45", "USD")
+	x, _ := currency.NewAmount("3.45", "EUR")
+	_, err = currency.CommonScale(a, x)
+	if e, ok := err.(currency.MismatchError); ok {
+		if e.A != a {
+			t.Errorf("got %v, want %v", e.A, a)
+		}
+		if e.B != x {
+			t.Errorf("got %v, want %v", e.B, x)
+		}
+	} else {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+
+	tests := []struct {
+		numbers []string
+		want    uint8
+	}{
+		{[]string{"5"}, 0},
+		{[]string{"5", "5.00", "5.0001"}, 4},
+		{[]string{"5.0001", "5.00", "5"}, 4},
+		{[]string{"-5.123", "0.10"}, 3},
+		{[]string{"1.2E+3", "1"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			var amounts []currency.Amount
+			for _, n := range tt.numbers {
+				a, _ := currency.NewAmount(n, "USD")
+				amounts = append(amounts, a)
+			}
+			got, err := currency.CommonScale(amounts...)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundAll(t *testing.T) {
+	_, err := currency.RoundAll([]currency.Amount{{}}, currency.RoundHalfUp)
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "" {
+			t.Errorf("got %v, want empty", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	got, err := currency.RoundAll(nil, currency.RoundHalfUp)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %v, want no amounts", got)
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		want         string
+		wantDown     string
+	}{
+		{"12.345", "USD", "12.35", "12.34"},
+		{"1234.5", "JPY", "1235", "1234"},
+		{"1.2345", "KWD", "1.235", "1.234"},
+		{"-0.125", "EUR", "-0.13", "-0.12"},
+		{"5", "USD", "5.00", "5.00"},
+	}
+	amounts := make([]currency.Amount, 0, len(tests))
+	for _, tt := range tests {
+		a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+		amounts = append(amounts, a)
+	}
+	got, err = currency.RoundAll(amounts, currency.RoundHalfUp)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	gotDown, err := currency.RoundAll(amounts, currency.RoundDown)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if len(got) != len(tests) || len(gotDown) != len(tests) {
+		t.Fatalf("got %v and %v amounts, want %v", len(got), len(gotDown), len(tests))
+	}
+	for i, tt := range tests {
+		if got[i].Number() != tt.want {
+			t.Errorf("got %v, want %v", got[i].Number(), tt.want)
+		}
+		if gotDown[i].Number() != tt.wantDown {
+			t.Errorf("got %v, want %v", gotDown[i].Number(), tt.wantDown)
+		}
+		if got[i].CurrencyCode() != tt.currencyCode {
+			t.Errorf("got %v, want %v", got[i].CurrencyCode(), tt.currencyCode)
+		}
+		// Confirm that the original amount is unchanged.
+		if amounts[i].Number() != tt.number {
+			t.Errorf("got %v, want %v", amounts[i].Number(), tt.number)
+		}
+	}
+}
+
+func TestWeightedAverage(t *testing.T) {
+	_, err := currency.WeightedAverage(nil)
+	if err != currency.ErrNoAmounts {
+		t.Errorf("got %v, want currency.ErrNoAmounts", err)
+	}
+
+	price := func(n string) currency.Amount {
+		a, _ := currency.NewAmount(n, "USD")
+		return a
+	}
+	tests := []struct {
+		items []currency.PriceQuantity
+		want  string
+	}{
+		{[]currency.PriceQuantity{{price("10.00"), "1"}}, "10"},
+		{[]currency.PriceQuantity{{price("10.00"), "3"}, {price("20.00"), "1"}}, "12.5"},
+		{[]currency.PriceQuantity{{price("4.20"), "2.5"}, {price("5.10"), "0.5"}}, "4.35"},
+		{[]currency.PriceQuantity{{price("1"), "1"}, {price("2"), "1"}, {price("2"), "1"}}, "1.66666666666666666666666666666666666667"},
+		{[]currency.PriceQuantity{{price("10"), "5"}, {price("8"), "-2"}}, "11.3333333333333333333333333333333333333"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := currency.WeightedAverage(tt.items)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.Number() != tt.want {
+				t.Errorf("got %v, want %v", got.Number(), tt.want)
+			}
+			if got.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", got.CurrencyCode())
+			}
+		})
+	}
+
+	_, err = currency.WeightedAverage([]currency.PriceQuantity{{price("10"), "2"}, {price("5"), "-2"}})
+	if err != currency.ErrZeroQuantity {
+		t.Errorf("got %v, want currency.ErrZeroQuantity", err)
+	}
+
+	_, err = currency.WeightedAverage([]currency.PriceQuantity{{price("10"), "INVALID"}})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "INVALID" {
+			t.Errorf("got %v, want INVALID", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+
+	x, _ := currency.NewAmount("3.45", "EUR")
+	_, err = currency.WeightedAverage([]currency.PriceQuantity{{price("10"), "1"}, {x, "1"}})
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+}
+
 func TestAmount_MarshalBinary(t *testing.T) {
 	a, _ := currency.NewAmount("3.45", "USD")
 	d, err := a.MarshalBinary()
@@ -731,13 +2365,13 @@ func TestAmount_UnmarshalJSON(t *testing.T) {
 		t.Errorf("got %T, want currency.InvalidNumberError", err)
 	}
 
-	d = []byte(`{"number":"3.45","currency":"usd"}`)
+	d = []byte(`{"number":"3.45","currency":"xyz"}`)
 	err = json.Unmarshal(d, unmarshalled)
 	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
-		if e.CurrencyCode != "usd" {
-			t.Errorf("got %v, want usd", e.CurrencyCode)
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
 		}
-		wantError := `invalid currency code "usd"`
+		wantError := `invalid currency code "xyz"`
 		if e.Error() != wantError {
 			t.Errorf("got %v, want %v", e.Error(), wantError)
 		}
@@ -756,6 +2390,38 @@ func TestAmount_UnmarshalJSON(t *testing.T) {
 	if unmarshalled.CurrencyCode() != "USD" {
 		t.Errorf("got %v, want USD", unmarshalled.CurrencyCode())
 	}
+
+	// JSON numbers are used as-is, without a float64 conversion.
+	for _, tt := range []struct{ d, want string }{
+		{`{"number": 0.1, "currency":"USD"}`, "0.1"},
+		{`{"number": 3.10, "currency":"USD"}`, "3.10"},
+		{`{"number": 12345678901234567890.123456789, "currency":"USD"}`, "12345678901234567890.123456789"},
+		{`{"number": -5, "currency":"USD"}`, "-5"},
+	} {
+		unmarshalled := &currency.Amount{}
+		err = json.Unmarshal([]byte(tt.d), unmarshalled)
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if unmarshalled.Number() != tt.want {
+			t.Errorf("got %v, want %v", unmarshalled.Number(), tt.want)
+		}
+	}
+
+	for _, tt := range []struct{ d, want string }{
+		{`{"number": null, "currency":"USD"}`, ""},
+		{`{"currency":"USD"}`, ""},
+		{`{"number": true, "currency":"USD"}`, "true"},
+	} {
+		err = json.Unmarshal([]byte(tt.d), &currency.Amount{})
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != tt.want {
+				t.Errorf("got %v, want %v", e.Number, tt.want)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
 }
 
 func TestAmount_Value(t *testing.T) {
@@ -807,3 +2473,63 @@ func TestAmount_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeChange(t *testing.T) {
+	a, _ := currency.NewAmount("3.75", "USD")
+	x, _ := currency.NewAmount("1", "EUR")
+	_, _, err := currency.MakeChange(a, []currency.Amount{x})
+	if _, ok := err.(currency.MismatchError); !ok {
+		t.Errorf("got %T, want currency.MismatchError", err)
+	}
+	zero, _ := currency.NewAmount("0", "USD")
+	_, _, err = currency.MakeChange(a, []currency.Amount{zero})
+	if _, ok := err.(currency.InvalidNumberError); !ok {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+	negative, _ := currency.NewAmount("-3.75", "USD")
+	_, _, err = currency.MakeChange(negative, nil)
+	if _, ok := err.(currency.NegativeAmountError); !ok {
+		t.Errorf("got %T, want currency.NegativeAmountError", err)
+	}
+
+	tests := []struct {
+		number        string
+		denominations []string
+		wantCounts    map[string]int
+		wantRemainder string
+	}{
+		{"3.75", []string{"1", "0.25", "0.10"}, map[string]int{"1": 3, "0.25": 3}, "0.00"},
+		// Denominations are used largest first, regardless of order.
+		{"3.75", []string{"0.10", "0.25", "1"}, map[string]int{"1": 3, "0.25": 3}, "0.00"},
+		{"0.85", []string{"0.25", "0.10", "0.05"}, map[string]int{"0.25": 3, "0.1": 1}, "0.00"},
+		// No exact change.
+		{"3.77", []string{"1", "0.25", "0.10"}, map[string]int{"1": 3, "0.25": 3}, "0.02"},
+		{"0.50", []string{"1"}, map[string]int{}, "0.50"},
+		{"0.50", nil, map[string]int{}, "0.50"},
+		// Keys don't depend on the scale of the denominations.
+		{"12.50", []string{"10.00", "1", "0.50"}, map[string]int{"10": 1, "1": 2, "0.5": 1}, "0.00"},
+		{"2", []string{"1.00", "1"}, map[string]int{"1": 2}, "0.00"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, "USD")
+			denominations := make([]currency.Amount, len(tt.denominations))
+			for i, d := range tt.denominations {
+				denominations[i], _ = currency.NewAmount(d, "USD")
+			}
+			counts, remainder, err := currency.MakeChange(a, denominations)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(counts, tt.wantCounts) {
+				t.Errorf("got %v, want %v", counts, tt.wantCounts)
+			}
+			if remainder.Number() != tt.wantRemainder {
+				t.Errorf("got %v, want %v", remainder.Number(), tt.wantRemainder)
+			}
+			if remainder.CurrencyCode() != "USD" {
+				t.Errorf("got %v, want USD", remainder.CurrencyCode())
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 var result currency.Amount
 var cmpResult int
+var formatResult string
 
 func BenchmarkNewAmount(b *testing.B) {
 	var z currency.Amount
@@ -118,3 +119,50 @@ func BenchmarkAmount_Cmp(b *testing.B) {
 	}
 	cmpResult = z
 }
+
+func BenchmarkFormatter_Format(b *testing.B) {
+	x, _ := currency.NewAmount("1234567.99", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+
+	var z string
+	for n := 0; n < b.N; n++ {
+		z = formatter.Format(x)
+	}
+	formatResult = z
+}
+
+func BenchmarkFormatter_FormatLarge(b *testing.B) {
+	x, _ := currency.NewAmount("12345678901234567890123456789012345678901234567890", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("hi"))
+
+	var z string
+	for n := 0; n < b.N; n++ {
+		z = formatter.Format(x)
+	}
+	formatResult = z
+}
+
+func BenchmarkGetSymbol(b *testing.B) {
+	currencyCodes := []string{
+		"USD", "EUR", "GBP", "JPY", "CNY", "CHF", "CAD", "AUD", "NZD", "HKD",
+		"SGD", "SEK", "NOK", "DKK", "PLN", "CZK", "HUF", "RON", "BGN", "HRK",
+		"RUB", "UAH", "TRY", "ILS", "AED", "SAR", "QAR", "KWD", "EGP", "ZAR",
+		"NGN", "KES", "INR", "PKR", "BDT", "LKR", "THB", "VND", "IDR", "MYR",
+		"PHP", "KRW", "TWD", "MXN", "BRL", "ARS", "CLP", "COP", "PEN", "UYU",
+	}
+	localeIDs := []string{"en", "en-GB", "de-CH", "fr-CA", "es-MX", "pt-BR", "ja", "zh-Hant-HK", "ar-EG", "sr-Latn-BA"}
+	locales := make([]currency.Locale, 0, len(localeIDs))
+	for _, id := range localeIDs {
+		locales = append(locales, currency.NewLocale(id))
+	}
+
+	var z string
+	for n := 0; n < b.N; n++ {
+		for _, locale := range locales {
+			for _, code := range currencyCodes {
+				z, _ = currency.GetSymbol(code, locale)
+			}
+		}
+	}
+	formatResult = z
+}
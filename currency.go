@@ -4,11 +4,208 @@
 // Package currency handles currency amounts, provides currency information and formatting.
 package currency
 
-import "sort"
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // DefaultDigits is a placeholder for each currency's number of fraction digits.
 const DefaultDigits uint8 = 255
 
+// CurrencyData holds the data used to register a currency.
+//
+// Example:
+//
+//	currency.CurrencyData{
+//		CurrencyCode: "USD",
+//		NumericCode:  "840",
+//		Digits:       2,
+//		Symbols: map[string]string{
+//			"en":    "$",
+//			"en-CA": "US$",
+//			"fr":    "$US",
+//		},
+//	}
+type CurrencyData struct {
+	// CurrencyCode is the three-letter currency code, e.g. "USD".
+	CurrencyCode string
+	// NumericCode is the three-digit numeric code, e.g. "840".
+	// Optional for currencies outside of ISO 4217 (e.g. "BTC"),
+	// defaults to "000", or to the existing code of a known currency.
+	NumericCode string
+	// Digits is the number of fraction digits, e.g. 2.
+	// When registering a known currency, zero keeps the existing digits.
+	Digits uint8
+	// Symbols maps locale IDs to symbols.
+	// When a locale has no symbol, the symbol of its parent is used.
+	// The "en" symbol is used for all locales without a more specific
+	// symbol, and defaults to the currency code.
+	//
+	// When registering a known currency, the given symbols are
+	// merged with the existing ones.
+	Symbols map[string]string
+}
+
+// RegisterCurrencyData registers a new currency, or replaces the data
+// of a known currency.
+//
+// Allows overriding the embedded CLDR data, e.g. to add a currency
+// or to update a currency symbol without upgrading this package.
+//
+// Also used for currencies outside of ISO 4217, such as cryptocurrencies:
+//
+//	func init() {
+//		currency.RegisterCurrencyData(currency.CurrencyData{
+//			CurrencyCode: "BTC",
+//			Digits:       8,
+//			Symbols:      map[string]string{"en": "₿"},
+//		})
+//	}
+//
+// Registration is not safe for concurrent use, and must happen at init,
+// before any amount is created or formatted.
+func RegisterCurrencyData(data CurrencyData) error {
+	if !isValidCode(data.CurrencyCode) {
+		return InvalidCurrencyCodeError{data.CurrencyCode}
+	}
+	currencyCode := data.CurrencyCode
+	info, known := currencies[currencyCode]
+	if !known {
+		info.numericCode = "000"
+	}
+	if data.NumericCode != "" {
+		info.numericCode = data.NumericCode
+	}
+	if !isValidNumericCode(info.numericCode) {
+		return InvalidNumberError{data.NumericCode}
+	}
+	if data.Digits != 0 || !known {
+		info.digits = data.Digits
+	}
+	if !known {
+		currencyCodes = append(currencyCodes, currencyCode)
+		sort.Strings(currencyCodes)
+	}
+	currencies[currencyCode] = info
+	if len(data.Symbols) > 0 {
+		registerSymbols(currencyCode, data.Symbols)
+	}
+
+	return nil
+}
+
+// FormatSpec holds the data used to register a locale format.
+//
+// Mirrors the number formatting data of CLDR locales.
+//
+// Example:
+//
+//	currency.FormatSpec{
+//		Pattern:               "¤\u00a00.00;¤-0.00",
+//		MinGroupingDigits:     1,
+//		PrimaryGroupingSize:   3,
+//		SecondaryGroupingSize: 3,
+//		DecimalSeparator:      ".",
+//		GroupingSeparator:     "’",
+//		PlusSign:              "+",
+//		MinusSign:             "-",
+//	}
+type FormatSpec struct {
+	// Pattern is the currency pattern, e.g. "¤0.00" or "0.00\u00a0¤".
+	// A separate negative pattern can follow a ";", e.g. "¤0.00;(¤0.00)".
+	// Each pattern must contain the "0.00" number placeholder.
+	Pattern string
+	// NumberingSystem is the numbering system used for digits,
+	// e.g. "arab". Defaults to "latn". Unknown values are ignored.
+	NumberingSystem string
+	// MinGroupingDigits is the minimum number of major digits required
+	// for grouping, e.g. 2 means that 1000 is not grouped, but 10000 is.
+	MinGroupingDigits uint8
+	// PrimaryGroupingSize is the size of the group closest to the decimal
+	// separator, e.g. 3. Zero turns off grouping.
+	PrimaryGroupingSize uint8
+	// SecondaryGroupingSize is the size of the other groups, e.g. 2 for "hi".
+	// Zero means the same as PrimaryGroupingSize.
+	SecondaryGroupingSize uint8
+	// DecimalSeparator is the decimal separator, e.g. ".".
+	DecimalSeparator string
+	// GroupingSeparator is the grouping separator, e.g. ",".
+	// Must differ from DecimalSeparator. Required even if grouping is off.
+	GroupingSeparator string
+	// PlusSign is the plus sign, e.g. "+". Required.
+	PlusSign string
+	// MinusSign is the minus sign, e.g. "-". Required.
+	MinusSign string
+}
+
+// InvalidFormatSpecError is returned when a FormatSpec is malformed.
+type InvalidFormatSpecError struct {
+	LocaleID string
+}
+
+func (e InvalidFormatSpecError) Error() string {
+	return fmt.Sprintf("invalid format spec for locale %q", e.LocaleID)
+}
+
+// RegisterLocaleFormat registers the number format for a locale,
+// or replaces the format of a known locale.
+//
+// Allows formatting amounts for locales which are not in CLDR, or
+// customizing the separators of a known locale. The locale's child
+// locales use the registered format too, unless they have their own.
+// Symbols are still resolved via the locale's parents (e.g. "en").
+//
+// Returns an InvalidLocaleError if the locale ID has no valid language, and an
+// InvalidFormatSpecError if the pattern is invalid, a separator or sign is
+// empty, or the decimal and grouping separators are identical.
+//
+// Registration is not safe for concurrent use, and must happen at init,
+// before any formatter is created.
+func RegisterLocaleFormat(localeID string, spec FormatSpec) error {
+	// ParseLocale can't be used, it rejects languages without CLDR data.
+	locale := NewLocale(localeID)
+	if locale.IsEmpty() {
+		return InvalidLocaleError{localeID}
+	}
+	// Empty separators and signs can't be parsed back, and an empty
+	// minus sign would make negative amounts look positive.
+	if spec.Pattern == "" || spec.DecimalSeparator == "" || spec.GroupingSeparator == "" {
+		return InvalidFormatSpecError{localeID}
+	}
+	if spec.PlusSign == "" || spec.MinusSign == "" {
+		return InvalidFormatSpecError{localeID}
+	}
+	// Identical separators would make formatted amounts ambiguous.
+	if spec.DecimalSeparator == spec.GroupingSeparator {
+		return InvalidFormatSpecError{localeID}
+	}
+	for _, pattern := range strings.Split(spec.Pattern, ";") {
+		if !strings.Contains(pattern, "0.00") {
+			return InvalidFormatSpecError{localeID}
+		}
+	}
+	if spec.SecondaryGroupingSize == 0 {
+		spec.SecondaryGroupingSize = spec.PrimaryGroupingSize
+	}
+	currencyFormats[locale.String()] = currencyFormat{
+		pattern:               spec.Pattern,
+		numberingSystem:       numberingSystemNames[spec.NumberingSystem],
+		minGroupingDigits:     spec.MinGroupingDigits,
+		primaryGroupingSize:   spec.PrimaryGroupingSize,
+		secondaryGroupingSize: spec.SecondaryGroupingSize,
+		decimalSeparator:      spec.DecimalSeparator,
+		groupingSeparator:     spec.GroupingSeparator,
+		plusSign:              spec.PlusSign,
+		minusSign:             spec.MinusSign,
+	}
+	knownLanguages[locale.Language] = true
+
+	return nil
+}
+
 // GetCurrencyCodes returns all known currency codes.
 func GetCurrencyCodes() []string {
 	return currencyCodes
@@ -17,17 +214,40 @@ func GetCurrencyCodes() []string {
 // IsValid checks whether a currencyCode is valid.
 //
 // An empty currencyCode is considered valid.
+// Currency codes are case-insensitive, "usd" and "USD" are both valid.
 func IsValid(currencyCode string) bool {
 	if currencyCode == "" {
 		return true
 	}
-	_, ok := currencies[currencyCode]
+	_, ok := currencies[canonicalCode(currencyCode)]
 
 	return ok
 }
 
+// ValidateCodes returns the unknown or malformed currency codes.
+//
+// Each invalid code is returned once, in the order of first appearance.
+// Unlike IsValid, an empty currency code is considered invalid.
+func ValidateCodes(currencyCodes []string) (invalid []string) {
+	seen := make(map[string]bool)
+	for _, currencyCode := range currencyCodes {
+		if currencyCode != "" && IsValid(currencyCode) {
+			continue
+		}
+		if !seen[currencyCode] {
+			seen[currencyCode] = true
+			invalid = append(invalid, currencyCode)
+		}
+	}
+	return invalid
+}
+
 // GetNumericCode returns the numeric code for a currencyCode.
+//
+// The numeric code is always zero-padded to 3 digits ("008" for ALL),
+// as required by ISO 4217, ISO 20022 and similar formats.
 func GetNumericCode(currencyCode string) (numericCode string, ok bool) {
+	currencyCode = canonicalCode(currencyCode)
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return "000", false
 	}
@@ -36,17 +256,34 @@ func GetNumericCode(currencyCode string) (numericCode string, ok bool) {
 
 // GetDigits returns the number of fraction digits for a currencyCode.
 func GetDigits(currencyCode string) (digits uint8, ok bool) {
+	currencyCode = canonicalCode(currencyCode)
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return 0, false
 	}
 	return currencies[currencyCode].digits, true
 }
 
+// GetRoundingIncrement returns the smallest amount representable
+// in a currencyCode, e.g. "0.01" for USD and "1" for JPY.
+//
+// Suitable for the step attribute of HTML number inputs.
+func GetRoundingIncrement(currencyCode string) (increment string, ok bool) {
+	digits, ok := GetDigits(currencyCode)
+	if !ok {
+		return "", false
+	}
+	if digits == 0 {
+		return "1", true
+	}
+	return "0." + strings.Repeat("0", int(digits)-1) + "1", true
+}
+
 // GetSymbol returns the symbol for a currencyCode.
 func GetSymbol(currencyCode string, locale Locale) (symbol string, ok bool) {
 	if currencyCode == "" || !IsValid(currencyCode) {
 		return currencyCode, false
 	}
+	currencyCode = canonicalCode(currencyCode)
 	symbols, ok := currencySymbols[currencyCode]
 	if !ok {
 		return currencyCode, true
@@ -57,29 +294,234 @@ func GetSymbol(currencyCode string, locale Locale) (symbol string, ok bool) {
 		// The "en"/"en-US" symbol is always first.
 		return symbols[0].symbol, true
 	}
+	symbol, _ = findSymbol(currencyCode, locale)
 
-	for {
-		localeID := locale.String()
+	return symbol, true
+}
+
+// GetNumericCodeErr is like GetNumericCode, but returns an
+// InvalidCurrencyCodeError for unknown currency codes.
+func GetNumericCodeErr(currencyCode string) (string, error) {
+	numericCode, ok := GetNumericCode(currencyCode)
+	if !ok {
+		return "", InvalidCurrencyCodeError{currencyCode}
+	}
+	return numericCode, nil
+}
+
+// GetDigitsErr is like GetDigits, but returns an
+// InvalidCurrencyCodeError for unknown currency codes.
+func GetDigitsErr(currencyCode string) (uint8, error) {
+	digits, ok := GetDigits(currencyCode)
+	if !ok {
+		return 0, InvalidCurrencyCodeError{currencyCode}
+	}
+	return digits, nil
+}
+
+// GetSymbolErr is like GetSymbol, but returns an
+// InvalidCurrencyCodeError for unknown currency codes.
+func GetSymbolErr(currencyCode string, locale Locale) (string, error) {
+	symbol, ok := GetSymbol(currencyCode, locale)
+	if !ok {
+		return "", InvalidCurrencyCodeError{currencyCode}
+	}
+	return symbol, nil
+}
+
+// GetSymbolWidth returns the display width of the symbol for a currencyCode,
+// in terminal columns.
+//
+// East Asian wide and fullwidth characters (e.g. "￥") count as 2 columns,
+// while marks and formatting characters (e.g. U+200E) count as 0.
+func GetSymbolWidth(currencyCode string, locale Locale) int {
+	symbol, _ := GetSymbol(currencyCode, locale)
+	width := 0
+	for _, r := range symbol {
+		switch {
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+			// Zero width.
+		case isWide(r):
+			width += 2
+		default:
+			width++
+		}
+	}
+	return width
+}
+
+// isWide returns whether r is an East Asian wide or fullwidth character.
+func isWide(r rune) bool {
+	return (r >= 0x1100 && r <= 0x115f) ||
+		(r >= 0x2e80 && r <= 0x303e) ||
+		(r >= 0x3041 && r <= 0x33ff) ||
+		(r >= 0x3400 && r <= 0x4dbf) ||
+		(r >= 0x4e00 && r <= 0x9fff) ||
+		(r >= 0xa000 && r <= 0xa4cf) ||
+		(r >= 0xac00 && r <= 0xd7a3) ||
+		(r >= 0xf900 && r <= 0xfaff) ||
+		(r >= 0xfe30 && r <= 0xfe4f) ||
+		(r >= 0xff00 && r <= 0xff60) ||
+		(r >= 0xffe0 && r <= 0xffe6) ||
+		(r >= 0x20000 && r <= 0x3fffd)
+}
+
+// AllSymbols returns the symbol of a currencyCode in every known locale,
+// keyed by locale ID.
+//
+// Each known locale is listed with its effective symbol (as returned
+// by GetSymbol), e.g. both "en" and "en-US" map to "$" for USD. Known locales are the ones in the loaded locale and symbol
+// data. Locales using the currency code as the symbol are omitted.
+func AllSymbols(currencyCode string) map[string]string {
+	currencyCode = canonicalCode(currencyCode)
+	if currencyCode == "" || !IsValid(currencyCode) {
+		return nil
+	}
+	symbols := make(map[string]string)
+	for localeID := range knownLocaleIDs() {
+		symbol, _ := findSymbol(currencyCode, NewLocale(localeID))
+		if symbol != "" && symbol != currencyCode {
+			symbols[localeID] = symbol
+		}
+	}
+
+	return symbols
+}
+
+// knownLocaleIDs returns the IDs of all locales in the loaded data.
+func knownLocaleIDs() map[string]bool {
+	localeIDs := map[string]bool{"en-US": true}
+	for localeID := range currencyFormats {
+		localeIDs[localeID] = true
+	}
+	for localeID, parentID := range parentLocales {
+		localeIDs[localeID] = true
+		localeIDs[parentID] = true
+	}
+	for _, symbols := range currencySymbols {
 		for _, s := range symbols {
-			if contains(s.locales, localeID) {
-				symbol = s.symbol
-				break
+			for _, localeID := range s.locales {
+				localeIDs[localeID] = true
 			}
 		}
-		if symbol != "" {
-			break
+	}
+	return localeIDs
+}
+
+// GuessCurrency returns the currency code most likely used in s.
+//
+// A currency code next to the number (e.g. "USD 10", "10 CHF") is always
+// preferred. Codes used as words ("ALL PRICES 10€") are ignored.
+// Otherwise, s is searched for the symbols of all currencies, in any
+// locale. Longer symbols are preferred to shorter ones ("CA$" over "A$").
+// Ambiguous symbols resolve to the currency which the given locale uses
+// the symbol for, most specifically, so that "$" is USD in "en-US",
+// but CAD in "en-CA". Any remaining ties are broken by the order of
+// GetCurrencyCodes.
+func GuessCurrency(s string, locale Locale) (currencyCode string, ok bool) {
+	for i := 0; i+3 <= len(s); i++ {
+		if !isASCIIUpper(s[i]) || (i > 0 && isASCIIUpper(s[i-1])) {
+			continue
+		}
+		if i+3 < len(s) && isASCIIUpper(s[i+3]) {
+			continue
+		}
+		if !isNextToNumber(s, i, i+3) {
+			continue
+		}
+		if code := s[i : i+3]; isValidCode(code) && IsValid(code) {
+			return code, true
+		}
+	}
+
+	bestLen, bestDepth, bestLocal := 0, 0, false
+	for _, code := range currencyCodes {
+		localSymbol, depth := findSymbol(code, locale)
+		for _, info := range currencySymbols[code] {
+			symbol := info.symbol
+			if symbol == code || !containsSymbol(s, symbol) {
+				continue
+			}
+			symbolLen := utf8.RuneCountInString(symbol)
+			local := symbol == localSymbol
+			switch {
+			case symbolLen < bestLen:
+				continue
+			case symbolLen == bestLen:
+				if !local || (bestLocal && depth >= bestDepth) {
+					continue
+				}
+			}
+			currencyCode = code
+			bestLen, bestDepth, bestLocal = symbolLen, depth, local
+		}
+	}
+
+	return currencyCode, currencyCode != ""
+}
+
+// CurrenciesWithSymbol returns the currency codes which use the given
+// symbol in the given locale, in the order of GetCurrencyCodes.
+//
+// The result is locale-dependent: "$" returns CAD in "en-CA",
+// but USD in "en", where CAD uses "CA$".
+func CurrenciesWithSymbol(symbol string, locale Locale) []string {
+	var result []string
+	for _, code := range currencyCodes {
+		if s, _ := GetSymbol(code, locale); s == symbol {
+			result = append(result, code)
+		}
+	}
+
+	return result
+}
+
+// findSymbol finds the symbol for a locale, falling back to its parents.
+//
+// Returns the symbol and how many parents were walked to find it.
+func findSymbol(currencyCode string, locale Locale) (symbol string, depth int) {
+	localeSymbols := symbolIndex[currencyCode]
+	for {
+		if symbol, ok := localeSymbols[locale.String()]; ok {
+			return symbol, depth
 		}
 		locale = locale.GetParent()
 		if locale.IsEmpty() {
 			break
 		}
+		depth++
 	}
 
-	return symbol, true
+	return "", depth
 }
 
-// getFormat returns the format for a locale.
-func getFormat(locale Locale) currencyFormat {
+// symbolIndex maps currency codes to locale IDs to symbols.
+//
+// Flattened from currencySymbols, for faster lookups.
+var symbolIndex = func() map[string]map[string]string {
+	index := make(map[string]map[string]string, len(currencySymbols))
+	for currencyCode := range currencySymbols {
+		index[currencyCode] = indexSymbols(currencySymbols[currencyCode])
+	}
+	return index
+}()
+
+// indexSymbols returns the given symbols keyed by locale ID.
+func indexSymbols(symbols []symbolInfo) map[string]string {
+	localeSymbols := make(map[string]string)
+	for _, s := range symbols {
+		for _, localeID := range s.locales {
+			if _, ok := localeSymbols[localeID]; !ok {
+				localeSymbols[localeID] = s.symbol
+			}
+		}
+	}
+	return localeSymbols
+}
+
+// getFormat returns the format for a locale, along with the locale
+// whose format was selected (e.g. "es-MX" => "es-419").
+func getFormat(locale Locale) (currencyFormat, Locale) {
 	var format currencyFormat
 	// CLDR considers "en" and "en-US" to be equivalent.
 	// Fall back immediately for better performance
@@ -99,7 +541,189 @@ func getFormat(locale Locale) currencyFormat {
 		}
 	}
 
-	return format
+	return format, locale
+}
+
+// getFormatWithFallback returns the currency format for the given locale,
+// trying the given fallbacks before the locale's parents.
+//
+// The locale is looked up without its parents. Each fallback is looked up
+// with its parents of the same language (e.g. "es-MX" => "es-419"), so that
+// a fallback without data of its own doesn't always match via "en".
+func getFormatWithFallback(locale Locale, fallbacks []Locale) (currencyFormat, Locale) {
+	enUSLocale := Locale{Language: "en", Territory: "US"}
+	l := locale
+	if l == enUSLocale {
+		l = Locale{Language: "en"}
+	}
+	if cf, ok := currencyFormats[l.String()]; ok {
+		return cf, l
+	}
+	for _, fallback := range fallbacks {
+		if fallback == enUSLocale {
+			fallback = Locale{Language: "en"}
+		}
+		for l := fallback; !l.IsEmpty() && l.Language == fallback.Language; l = l.GetParent() {
+			if cf, ok := currencyFormats[l.String()]; ok {
+				return cf, l
+			}
+		}
+	}
+
+	return getFormat(locale)
+}
+
+// canonicalCode returns the uppercase form of a currencyCode ("usd" => "USD").
+//
+// Only ASCII letters are folded. Codes containing non-ASCII characters
+// are returned unchanged, so that they never match a known currency
+// (strings.ToUpper would turn "uſd" into "USD").
+func canonicalCode(currencyCode string) string {
+	hasLower := false
+	for i := 0; i < len(currencyCode); i++ {
+		c := currencyCode[i]
+		if c >= utf8.RuneSelf {
+			return currencyCode
+		}
+		if c >= 'a' && c <= 'z' {
+			hasLower = true
+		}
+	}
+	if !hasLower {
+		return currencyCode
+	}
+	b := []byte(currencyCode)
+	for i, c := range b {
+		if c >= 'a' && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+	return string(b)
+}
+
+// registerSymbols merges the given symbols into the currency's existing symbols.
+func registerSymbols(currencyCode string, symbols map[string]string) {
+	localeSymbols := make(map[string]string)
+	for _, s := range currencySymbols[currencyCode] {
+		for _, localeID := range s.locales {
+			localeSymbols[localeID] = s.symbol
+		}
+	}
+	for localeID, symbol := range symbols {
+		localeID = NewLocale(localeID).String()
+		localeSymbols[localeID] = symbol
+	}
+	if _, ok := localeSymbols["en"]; !ok {
+		localeSymbols["en"] = currencyCode
+	}
+
+	groups := make(map[string][]string)
+	for localeID, symbol := range localeSymbols {
+		groups[symbol] = append(groups[symbol], localeID)
+	}
+	// Always put the "en" symbol first, then the other sorted symbols.
+	enSymbol := localeSymbols["en"]
+	var otherSymbols []string
+	for symbol := range groups {
+		if symbol != enSymbol {
+			otherSymbols = append(otherSymbols, symbol)
+		}
+	}
+	sort.Strings(otherSymbols)
+	result := make([]symbolInfo, 0, len(groups))
+	for _, symbol := range append([]string{enSymbol}, otherSymbols...) {
+		locales := groups[symbol]
+		sort.Strings(locales)
+		result = append(result, symbolInfo{symbol, locales})
+	}
+	currencySymbols[currencyCode] = result
+	symbolIndex[currencyCode] = indexSymbols(result)
+}
+
+// isValidCode returns whether s is a well-formed currency code (e.g. "USD").
+func isValidCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isASCIIUpper returns whether b is an uppercase ASCII letter.
+func isASCIIUpper(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+// isNextToNumber returns whether s[start:end] is next to a number,
+// with only whitespace, currency symbols or signs in between.
+func isNextToNumber(s string, start, end int) bool {
+	skip := func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.Is(unicode.Sc, r) || r == '+' || r == '-'
+	}
+	for _, r := range s[end:] {
+		if unicode.IsDigit(r) {
+			return true
+		}
+		if !skip(r) {
+			break
+		}
+	}
+	for i := start; i > 0; {
+		r, size := utf8.DecodeLastRuneInString(s[:i])
+		if unicode.IsDigit(r) {
+			return true
+		}
+		if !skip(r) {
+			break
+		}
+		i -= size
+	}
+	return false
+}
+
+// containsSymbol returns whether s contains the given symbol.
+//
+// Symbols starting or ending with a letter (e.g. "kr") must not be
+// part of a longer word.
+func containsSymbol(s, symbol string) bool {
+	if symbol == "" {
+		return false
+	}
+	first, _ := utf8.DecodeRuneInString(symbol)
+	last, _ := utf8.DecodeLastRuneInString(symbol)
+	for offset := 0; offset < len(s); {
+		i := strings.Index(s[offset:], symbol)
+		if i == -1 {
+			return false
+		}
+		start := offset + i
+		end := start + len(symbol)
+		before, _ := utf8.DecodeLastRuneInString(s[:start])
+		after, _ := utf8.DecodeRuneInString(s[end:])
+		if (!unicode.IsLetter(first) || start == 0 || !unicode.IsLetter(before)) &&
+			(!unicode.IsLetter(last) || end == len(s) || !unicode.IsLetter(after)) {
+			return true
+		}
+		offset = start + 1
+	}
+	return false
+}
+
+// isValidNumericCode returns whether s is a well-formed numeric code (e.g. "840").
+func isValidNumericCode(s string) bool {
+	if len(s) != 3 {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
 // contains returns whether the sorted slice a contains x.
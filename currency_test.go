@@ -4,6 +4,8 @@
 package currency_test
 
 import (
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/bojanz/currency"
@@ -28,9 +30,14 @@ func TestIsValid(t *testing.T) {
 		{"", true},
 		{"INVALID", false},
 		{"XXX", false},
-		{"usd", false},
+		{"xyz", false},
 		{"USD", true},
+		{"usd", true},
+		{"Usd", true},
 		{"EUR", true},
+		// Non-ASCII letters must not be folded into ASCII ones.
+		{"uſd", false},
+		{"ıls", false},
 	}
 
 	for _, tt := range tests {
@@ -43,6 +50,25 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestValidateCodes(t *testing.T) {
+	got := currency.ValidateCodes([]string{"USD", "XYZ", "xyz", "eur", "", "XYZ", "US", "xyz", "RSD"})
+	want := []string{"XYZ", "xyz", "", "US"}
+	if len(got) != len(want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	for i := range got {
+		if i < len(want) && got[i] != want[i] {
+			t.Errorf("got %q, want %q", got, want)
+			break
+		}
+	}
+
+	got = currency.ValidateCodes([]string{"USD", "EUR"})
+	if len(got) != 0 {
+		t.Errorf("got %q, want no invalid codes", got)
+	}
+}
+
 func TestGetNumericCode(t *testing.T) {
 	numericCode, ok := currency.GetNumericCode("USD")
 	if !ok {
@@ -52,6 +78,14 @@ func TestGetNumericCode(t *testing.T) {
 		t.Errorf("got %v, want 840", numericCode)
 	}
 
+	// Numeric codes under 100 are zero-padded.
+	for currencyCode, want := range map[string]string{"ALL": "008", "AUD": "036", "BHD": "048"} {
+		numericCode, _ := currency.GetNumericCode(currencyCode)
+		if numericCode != want {
+			t.Errorf("%v: got %v, want %v", currencyCode, numericCode, want)
+		}
+	}
+
 	// Non-existent currency code.
 	numericCode, ok = currency.GetNumericCode("XXX")
 	if ok {
@@ -81,6 +115,32 @@ func TestGetDigits(t *testing.T) {
 	}
 }
 
+func TestGetRoundingIncrement(t *testing.T) {
+	tests := []struct {
+		currencyCode string
+		want         string
+		wantOk       bool
+	}{
+		{"USD", "0.01", true},
+		{"JPY", "1", true},
+		{"KWD", "0.001", true},
+		{"CLF", "0.0001", true},
+		{"XXX", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode, func(t *testing.T) {
+			got, ok := currency.GetRoundingIncrement(tt.currencyCode)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if ok != tt.wantOk {
+				t.Errorf("got %v, want %v", ok, tt.wantOk)
+			}
+		})
+	}
+}
+
 func TestGetSymbol(t *testing.T) {
 	tests := []struct {
 		currencyCode string
@@ -89,7 +149,9 @@ func TestGetSymbol(t *testing.T) {
 		wantOk       bool
 	}{
 		{"XXX", currency.NewLocale("en"), "XXX", false},
-		{"usd", currency.NewLocale("en"), "usd", false},
+		{"xyz", currency.NewLocale("en"), "xyz", false},
+		{"usd", currency.NewLocale("en"), "$", true},
+		{"chf", currency.NewLocale("en"), "CHF", true},
 		{"CHF", currency.NewLocale("en"), "CHF", true},
 		{"USD", currency.NewLocale("en"), "$", true},
 		{"USD", currency.NewLocale("en-US"), "$", true},
@@ -110,3 +172,336 @@ func TestGetSymbol(t *testing.T) {
 		})
 	}
 }
+
+func TestGetSymbolWidth(t *testing.T) {
+	tests := []struct {
+		currencyCode string
+		localeID     string
+		want         int
+	}{
+		{"USD", "en", 1},
+		{"USD", "en-CA", 3},
+		{"JPY", "ja", 2},
+		{"CNY", "ja", 2},
+		{"KRW", "zh", 2},
+		{"CNY", "he", 3},
+		{"EUR", "de", 1},
+		{"XXX", "en", 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currencyCode+" "+tt.localeID, func(t *testing.T) {
+			got := currency.GetSymbolWidth(tt.currencyCode, currency.NewLocale(tt.localeID))
+			if got != tt.want {
+				symbol, _ := currency.GetSymbol(tt.currencyCode, currency.NewLocale(tt.localeID))
+				t.Errorf("got %v, want %v (%q)", got, tt.want, symbol)
+			}
+		})
+	}
+}
+
+func TestAllSymbols(t *testing.T) {
+	symbols := currency.AllSymbols("XXX")
+	if symbols != nil {
+		t.Errorf("got %v, want nil", symbols)
+	}
+
+	symbols = currency.AllSymbols("usd")
+	wantSymbols := map[string]string{
+		"en":    "$",
+		"en-US": "$",
+		"en-CA": "US$",
+		"es":    "US$",
+		"fr":    "$US",
+		"fr-CH": "$US",
+		"fr-CA": "$\u00a0US",
+		"bg":    "щ.д.",
+		"ja":    "$",
+	}
+	for localeID, want := range wantSymbols {
+		if symbols[localeID] != want {
+			t.Errorf("%v: got %v, want %v", localeID, symbols[localeID], want)
+		}
+	}
+	for localeID, symbol := range symbols {
+		want, _ := currency.GetSymbol("USD", currency.NewLocale(localeID))
+		if symbol != want {
+			t.Errorf("%v: got %v, want %v", localeID, symbol, want)
+		}
+	}
+
+	// Locales using the currency code are omitted.
+	symbols = currency.AllSymbols("CHF")
+	if len(symbols) != 0 {
+		t.Errorf("got %v, want no symbols", symbols)
+	}
+	symbols = currency.AllSymbols("UYU")
+	if _, ok := symbols["en"]; ok {
+		t.Errorf("expected no en symbol")
+	}
+	if symbols["es-UY"] != "$" {
+		t.Errorf("got %v, want $", symbols["es-UY"])
+	}
+}
+
+func TestErrVariants(t *testing.T) {
+	locale := currency.NewLocale("en")
+	numericCode, err := currency.GetNumericCodeErr("USD")
+	if err != nil || numericCode != "840" {
+		t.Errorf("got %v, %v, want 840, nil", numericCode, err)
+	}
+	digits, err := currency.GetDigitsErr("USD")
+	if err != nil || digits != 2 {
+		t.Errorf("got %v, %v, want 2, nil", digits, err)
+	}
+	symbol, err := currency.GetSymbolErr("USD", locale)
+	if err != nil || symbol != "$" {
+		t.Errorf("got %v, %v, want $, nil", symbol, err)
+	}
+
+	errs := []error{}
+	_, err = currency.GetNumericCodeErr("XYZ")
+	errs = append(errs, err)
+	_, err = currency.GetDigitsErr("XYZ")
+	errs = append(errs, err)
+	_, err = currency.GetSymbolErr("XYZ", locale)
+	errs = append(errs, err)
+	for _, err := range errs {
+		if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+			if e.CurrencyCode != "XYZ" {
+				t.Errorf("got %v, want XYZ", e.CurrencyCode)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+		}
+	}
+}
+
+func TestGuessCurrency(t *testing.T) {
+	tests := []struct {
+		s                string
+		localeID         string
+		wantCurrencyCode string
+		wantOk           bool
+	}{
+		{"€10", "en-US", "EUR", true},
+		{"10,00\u00a0€", "de", "EUR", true},
+		{"$10", "en-US", "USD", true},
+		{"$10", "en-CA", "CAD", true},
+		{"US$10", "en-CA", "USD", true},
+		{"CA$10", "en-US", "CAD", true},
+		{"10,00\u00a0$US", "fr", "USD", true},
+		{"10,00\u00a0$\u00a0US", "fr-CA", "USD", true},
+		{"10,00\u00a0$", "fr-CA", "CAD", true},
+		{"£10", "en-GB", "GBP", true},
+		{"￥1000", "ja", "JPY", true},
+		{"¥1000", "en", "JPY", true},
+		{"¥1000", "zh", "CNY", true},
+		// Currency codes are preferred.
+		{"USD 10", "en-CA", "USD", true},
+		{"10\u00a0CHF", "de-CH", "CHF", true},
+		{"CAD$10", "en-US", "CAD", true},
+		// Longer symbols from other locales are preferred.
+		{"CA$10", "en-CA", "CAD", true},
+		{"A$10", "en-CA", "AUD", true},
+		// Codes used as words are ignored.
+		{"ALL PRICES 10€", "en", "EUR", true},
+		{"TOP DEAL: €10", "en", "EUR", true},
+		{"ALL 10", "en", "ALL", true},
+		{"10 TOP", "en", "TOP", true},
+		// Unknown or partial codes are ignored.
+		{"XYZ 10", "en", "", false},
+		{"USDX 10", "en", "", false},
+		{"10", "en", "", false},
+		{"", "en", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			gotCurrencyCode, gotOk := currency.GuessCurrency(tt.s, locale)
+			if gotCurrencyCode != tt.wantCurrencyCode {
+				t.Errorf("got %v, want %v", gotCurrencyCode, tt.wantCurrencyCode)
+			}
+			if gotOk != tt.wantOk {
+				t.Errorf("got %v, want %v", gotOk, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestCurrenciesWithSymbol(t *testing.T) {
+	tests := []struct {
+		symbol   string
+		localeID string
+		want     []string
+	}{
+		{"$", "en-CA", []string{"CAD"}},
+		{"$", "en", []string{"USD"}},
+		{"CA$", "en", []string{"CAD"}},
+		{"$", "ms-BN", []string{"USD", "BND"}},
+		{"kr", "sv", []string{"SEK"}},
+		{"€", "fr", []string{"EUR"}},
+		{"XYZ", "en", nil},
+		{"", "en", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.symbol+" "+tt.localeID, func(t *testing.T) {
+			got := currency.CurrenciesWithSymbol(tt.symbol, currency.NewLocale(tt.localeID))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterCurrencyData(t *testing.T) {
+	currency.RestoreDataOnCleanup(t)
+	err := currency.RegisterCurrencyData(currency.CurrencyData{CurrencyCode: "xts", NumericCode: "963"})
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xts" {
+			t.Errorf("got %v, want xts", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	err = currency.RegisterCurrencyData(currency.CurrencyData{CurrencyCode: "XTS", NumericCode: "96"})
+	if e, ok := err.(currency.InvalidNumberError); ok {
+		if e.Number != "96" {
+			t.Errorf("got %v, want 96", e.Number)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+	if currency.IsValid("XTS") {
+		t.Errorf("expected XTS to not be registered after an error")
+	}
+
+	// New currency.
+	err = currency.RegisterCurrencyData(currency.CurrencyData{
+		CurrencyCode: "XTS",
+		NumericCode:  "963",
+		Digits:       3,
+		Symbols: map[string]string{
+			"fr": "T$",
+			"sr": "ТС",
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !currency.IsValid("XTS") {
+		t.Errorf("expected XTS to be valid")
+	}
+	codes := currency.GetCurrencyCodes()
+	if !sort.StringsAreSorted(codes) {
+		t.Errorf("expected currency codes to stay sorted")
+	}
+	if i := sort.SearchStrings(codes, "XTS"); i == len(codes) || codes[i] != "XTS" {
+		t.Errorf("expected XTS to be listed")
+	}
+	numericCode, _ := currency.GetNumericCode("XTS")
+	if numericCode != "963" {
+		t.Errorf("got %v, want 963", numericCode)
+	}
+	digits, _ := currency.GetDigits("XTS")
+	if digits != 3 {
+		t.Errorf("got %v, want 3", digits)
+	}
+	tests := []struct {
+		localeID   string
+		wantSymbol string
+	}{
+		{"en", "XTS"},
+		{"en-US", "XTS"},
+		{"de", "XTS"},
+		{"fr", "T$"},
+		{"fr-CA", "T$"},
+		{"sr", "ТС"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			symbol, _ := currency.GetSymbol("XTS", currency.NewLocale(tt.localeID))
+			if symbol != tt.wantSymbol {
+				t.Errorf("got %v, want %v", symbol, tt.wantSymbol)
+			}
+		})
+	}
+
+	// Updated symbols are merged with the existing ones.
+	err = currency.RegisterCurrencyData(currency.CurrencyData{
+		CurrencyCode: "XTS",
+		NumericCode:  "963",
+		Digits:       2,
+		Symbols: map[string]string{
+			"en": "T",
+			"fr": "TS",
+		},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	count := 0
+	for _, code := range currency.GetCurrencyCodes() {
+		if code == "XTS" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Errorf("expected XTS to be listed once, got %v", count)
+	}
+	digits, _ = currency.GetDigits("XTS")
+	if digits != 2 {
+		t.Errorf("got %v, want 2", digits)
+	}
+	tests = []struct {
+		localeID   string
+		wantSymbol string
+	}{
+		{"en", "T"},
+		{"de", "T"},
+		{"fr", "TS"},
+		{"sr", "ТС"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			symbol, _ := currency.GetSymbol("XTS", currency.NewLocale(tt.localeID))
+			if symbol != tt.wantSymbol {
+				t.Errorf("got %v, want %v", symbol, tt.wantSymbol)
+			}
+		})
+	}
+
+	// Unset fields keep the existing data of a known currency.
+	err = currency.RegisterCurrencyData(currency.CurrencyData{
+		CurrencyCode: "XTS",
+		Symbols:      map[string]string{"de": "TS"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	numericCode, _ = currency.GetNumericCode("XTS")
+	if numericCode != "963" {
+		t.Errorf("got %v, want 963", numericCode)
+	}
+	digits, _ = currency.GetDigits("XTS")
+	if digits != 2 {
+		t.Errorf("got %v, want 2", digits)
+	}
+	// Re-registers the existing "fr" symbol, leaving USD unchanged.
+	err = currency.RegisterCurrencyData(currency.CurrencyData{
+		CurrencyCode: "USD",
+		Symbols:      map[string]string{"fr": "$US"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	numericCode, _ = currency.GetNumericCode("USD")
+	if numericCode != "840" {
+		t.Errorf("got %v, want 840", numericCode)
+	}
+	amount, _ := currency.NewAmount("12.34", "USD")
+	if amount.Round().String() != "12.34 USD" {
+		t.Errorf("got %v, want 12.34 USD", amount.Round())
+	}
+}
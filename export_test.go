@@ -0,0 +1,44 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+import "testing"
+
+// RestoreDataOnCleanup snapshots the currency and locale data,
+// and restores it once the test finishes.
+//
+// Used by tests which register currencies or locale formats,
+// to keep the registered data from leaking into other tests.
+func RestoreDataOnCleanup(t *testing.T) {
+	savedCurrencies := make(map[string]currencyInfo, len(currencies))
+	for k, v := range currencies {
+		savedCurrencies[k] = v
+	}
+	savedCurrencyCodes := append([]string(nil), currencyCodes...)
+	savedCurrencySymbols := make(map[string][]symbolInfo, len(currencySymbols))
+	for k, v := range currencySymbols {
+		savedCurrencySymbols[k] = v
+	}
+	savedSymbolIndex := make(map[string]map[string]string, len(symbolIndex))
+	for k, v := range symbolIndex {
+		savedSymbolIndex[k] = v
+	}
+	savedCurrencyFormats := make(map[string]currencyFormat, len(currencyFormats))
+	for k, v := range currencyFormats {
+		savedCurrencyFormats[k] = v
+	}
+	savedKnownLanguages := make(map[string]bool, len(knownLanguages))
+	for k, v := range knownLanguages {
+		savedKnownLanguages[k] = v
+	}
+
+	t.Cleanup(func() {
+		currencies = savedCurrencies
+		currencyCodes = savedCurrencyCodes
+		currencySymbols = savedCurrencySymbols
+		symbolIndex = savedSymbolIndex
+		currencyFormats = savedCurrencyFormats
+		knownLanguages = savedKnownLanguages
+	})
+}
@@ -4,10 +4,13 @@
 package currency
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/cockroachdb/apd/v3"
 )
 
 // Display represents the currency display type.
@@ -22,6 +25,46 @@ const (
 	DisplayNone
 )
 
+// BidiMode represents the Unicode bidi controls wrapping the number.
+type BidiMode uint8
+
+const (
+	// BidiNone adds no bidi controls.
+	BidiNone BidiMode = iota
+	// BidiIsolate wraps the number in bidi isolates (FSI/PDI).
+	BidiIsolate
+	// BidiEmbedding wraps the number in a left-to-right embedding (LRE/PDF),
+	// for older renderers which don't support isolates.
+	BidiEmbedding
+)
+
+// ScaledUnit represents a unit used by FormatScaledUnit.
+type ScaledUnit uint8
+
+const (
+	// PerMille shows the amount in thousandths ("12.5‰" for 0.0125).
+	PerMille ScaledUnit = iota
+	// BasisPoints shows the amount in ten-thousandths ("125 bps" for 0.0125).
+	BasisPoints
+)
+
+// defaultAmbiguousCurrencies lists currencies that share a symbol
+// with other currencies (e.g. "$", "£", "¥", "kr").
+var defaultAmbiguousCurrencies = []string{
+	// Dollars.
+	"ARS", "AUD", "BBD", "BMD", "BND", "BSD", "BZD", "CAD", "CLP", "COP",
+	"CUP", "DOP", "FJD", "GYD", "HKD", "JMD", "KYD", "LRD", "MXN", "NAD",
+	"NZD", "SBD", "SGD", "SRD", "TTD", "TWD", "USD", "UYU", "XCD",
+	// Pounds.
+	"EGP", "FKP", "GBP", "GIP", "LBP", "SHP", "SSP", "SYP",
+	// Yen and yuan.
+	"CNY", "JPY",
+	// Kronor and kroner.
+	"DKK", "ISK", "NOK", "SEK",
+	// Rupees.
+	"INR", "LKR", "NPR", "PKR",
+}
+
 var localDigits = map[numberingSystem]string{
 	numArab:    "٠١٢٣٤٥٦٧٨٩",
 	numArabExt: "۰۱۲۳۴۵۶۷۸۹",
@@ -30,16 +73,72 @@ var localDigits = map[numberingSystem]string{
 	numMymr:    "၀၁၂၃၄၅၆၇၈၉",
 }
 
+// asciiDigits replaces localized digits of any numbering system with ASCII digits.
+var asciiDigits = func() *strings.Replacer {
+	replacements := make([]string, 0, 20*len(localDigits))
+	for _, digits := range localDigits {
+		for i, v := range strings.Split(digits, "") {
+			replacements = append(replacements, v, strconv.Itoa(i))
+		}
+	}
+	return strings.NewReplacer(replacements...)
+}()
+
+var superscriptDigits = strings.NewReplacer(
+	"0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+var numberingSystemNames = map[string]numberingSystem{
+	"latn":    numLatn,
+	"arab":    numArab,
+	"arabext": numArabExt,
+	"beng":    numBeng,
+	"deva":    numDeva,
+	"mymr":    numMymr,
+}
+
+// InvalidTemplateError is returned when a template has unknown or unclosed placeholders.
+type InvalidTemplateError struct {
+	Template string
+}
+
+func (e InvalidTemplateError) Error() string {
+	return fmt.Sprintf("invalid template %q", e.Template)
+}
+
+// InvalidUnitError is returned when a unit is empty or unrecognized.
+type InvalidUnitError struct {
+	Unit string
+}
+
+func (e InvalidUnitError) Error() string {
+	return fmt.Sprintf("invalid unit %q", e.Unit)
+}
+
+// FormattedAmount is a currency amount formatted as separate parts,
+// returned by Formatter.FormatSeparate.
+type FormattedAmount struct {
+	// Number is the formatted number, including the sign.
+	Number string
+	// Currency is the currency symbol or code, depending on CurrencyDisplay.
+	Currency string
+}
+
 // Formatter formats and parses currency amounts.
 type Formatter struct {
-	locale Locale
-	format currencyFormat
+	locale         Locale
+	resolvedLocale Locale
+	format         currencyFormat
 	// NoGrouping turns off grouping of major digits.
 	// Defaults to false.
 	NoGrouping bool
 	// AddPlusSign inserts the plus sign in front of positive amounts.
 	// Defaults to false.
 	AddPlusSign bool
+	// NoDeltaArrows omits the arrows (▲/▼) added by FormatDelta.
+	// Defaults to false.
+	NoDeltaArrows bool
 	// MinDigits specifies the minimum number of fraction digits.
 	// All zeroes past the minimum will be removed (0 => no trailing zeroes).
 	// Defaults to currency.DefaultDigits (e.g. 2 for USD, 0 for RSD).
@@ -47,7 +146,22 @@ type Formatter struct {
 	// MaxDigits specifies the maximum number of fraction digits.
 	// Formatted amounts will be rounded to this number of digits.
 	// Defaults to 6, so that most amounts are shown as-is (without rounding).
+	// While left at the default, amounts are never rounded to fewer than
+	// the currency's digits (e.g. 8 for a registered BTC).
 	MaxDigits uint8
+	// HideZeroFraction omits the fraction when all of its digits are zero,
+	// regardless of MinDigits ("$1,234" but "$1,234.50").
+	// Defaults to false.
+	HideZeroFraction bool
+	// MaxSignificantDigits specifies the maximum number of significant digits.
+	// Formatted amounts will be rounded to this number of digits first,
+	// before rounding to MaxDigits, e.g. 12345.678 => 12350 for 4 digits.
+	// Defaults to 0, meaning no limit.
+	MaxSignificantDigits uint8
+	// SuperscriptFraction shows the fraction digits in superscript,
+	// without the decimal separator ("$12⁹⁹").
+	// Defaults to false.
+	SuperscriptFraction bool
 	// RoundingMode specifies how the formatted amount will be rounded.
 	// One of the currency.Round* constants.
 	// Defaults to currency.RoundHalfUp.
@@ -56,23 +170,133 @@ type Formatter struct {
 	// One of the currency.Display* constants.
 	// Defaults to curency.DisplaySymbol.
 	CurrencyDisplay Display
+	// PreserveSymbolSpace replaces a hidden currency (currency.DisplayNone)
+	// with spaces matching the width of its symbol, keeping amounts
+	// aligned in columns where only some of them show the symbol.
+	// Defaults to false.
+	PreserveSymbolSpace bool
 	// SymbolMap specifies custom symbols for individual currency codes.
 	// For example, "USD": "$" means that the $ symbol will be used even if
 	// the current locale's symbol is different ("US$", "$US", etc).
 	SymbolMap map[string]string
+	// LocaleSymbolMap specifies custom symbols for individual locales.
+	// For example, "fr": {"EUR": "EUR€"} means that the "EUR€" symbol
+	// will be used for EUR in "fr" and its child locales ("fr-CA", etc).
+	// Symbols defined for "en" are not inherited by non-English locales.
+	// Takes precedence over SymbolMap.
+	LocaleSymbolMap map[string]map[string]string
+	// NegativeSymbolMap specifies custom symbols for negative amounts.
+	// For example, "USD": "CR $" means that negative USD amounts will
+	// use "CR $", while positive ones keep their usual symbol.
+	// Takes precedence over LocaleSymbolMap and SymbolMap.
+	NegativeSymbolMap map[string]string
+	// AmbiguousCurrencyDisplay specifies how ambiguous currencies will be
+	// displayed, when CurrencyDisplay is currency.DisplaySymbol.
+	// For example, currency.DisplayCode shows "USD" instead of "$",
+	// while unambiguous currencies such as EUR keep their symbol.
+	// Defaults to currency.DisplaySymbol.
+	AmbiguousCurrencyDisplay Display
+	// AmbiguousCurrencies specifies the currency codes considered ambiguous.
+	// Defaults to currencies which share a symbol ("$", "£", "¥", "kr", etc).
+	AmbiguousCurrencies []string
+	// BidiMode specifies the Unicode bidi controls wrapping the number,
+	// ensuring correct display when the amount is embedded in RTL text.
+	// One of the currency.Bidi* constants.
+	// Defaults to currency.BidiNone.
+	BidiMode BidiMode
+	// ASCIIMinusSign uses the ASCII minus ("-") and plus ("+") signs,
+	// instead of the locale's signs (e.g. "−", U+2212 in "sv").
+	// Defaults to false.
+	ASCIIMinusSign bool
+	// NumberingSystem overrides the locale's numbering system, e.g. "latn"
+	// for Latin digits (0-9) in the "ar" locale. Separators are unchanged.
+	// One of "latn", "arab", "arabext", "beng", "deva", "mymr".
+	// Defaults to "", using the locale's numbering system.
+	NumberingSystem string
 }
 
+// FormatOptions contains the formatter settings, for storing them
+// in configuration files (e.g. as JSON).
+//
+// See Formatter for a description of each setting.
+type FormatOptions struct {
+	NoGrouping               bool                         `json:"no_grouping"`
+	AddPlusSign              bool                         `json:"add_plus_sign"`
+	NoDeltaArrows            bool                         `json:"no_delta_arrows"`
+	MinDigits                uint8                        `json:"min_digits"`
+	MaxDigits                uint8                        `json:"max_digits"`
+	HideZeroFraction         bool                         `json:"hide_zero_fraction"`
+	MaxSignificantDigits     uint8                        `json:"max_significant_digits"`
+	SuperscriptFraction      bool                         `json:"superscript_fraction"`
+	RoundingMode             RoundingMode                 `json:"rounding_mode"`
+	CurrencyDisplay          Display                      `json:"currency_display"`
+	PreserveSymbolSpace      bool                         `json:"preserve_symbol_space"`
+	SymbolMap                map[string]string            `json:"symbol_map,omitempty"`
+	LocaleSymbolMap          map[string]map[string]string `json:"locale_symbol_map,omitempty"`
+	NegativeSymbolMap        map[string]string            `json:"negative_symbol_map,omitempty"`
+	AmbiguousCurrencyDisplay Display                      `json:"ambiguous_currency_display"`
+	AmbiguousCurrencies      []string                     `json:"ambiguous_currencies"`
+	BidiMode                 BidiMode                     `json:"bidi_mode"`
+	ASCIIMinusSign           bool                         `json:"ascii_minus_sign"`
+	NumberingSystem          string                       `json:"numbering_system,omitempty"`
+}
+
+// defaultMaxDigits is the default value of Formatter.MaxDigits.
+const defaultMaxDigits uint8 = 6
+
 // NewFormatter creates a new formatter for the given locale.
 func NewFormatter(locale Locale) *Formatter {
 	f := &Formatter{
-		locale:          locale,
-		format:          getFormat(locale),
-		MinDigits:       DefaultDigits,
-		MaxDigits:       6,
-		RoundingMode:    RoundHalfUp,
-		CurrencyDisplay: DisplaySymbol,
-		SymbolMap:       make(map[string]string),
+		locale:                   locale,
+		MinDigits:                DefaultDigits,
+		MaxDigits:                defaultMaxDigits,
+		RoundingMode:             RoundHalfUp,
+		CurrencyDisplay:          DisplaySymbol,
+		SymbolMap:                make(map[string]string),
+		LocaleSymbolMap:          make(map[string]map[string]string),
+		NegativeSymbolMap:        make(map[string]string),
+		AmbiguousCurrencyDisplay: DisplaySymbol,
+		AmbiguousCurrencies:      append([]string(nil), defaultAmbiguousCurrencies...),
 	}
+	f.format, f.resolvedLocale = getFormat(locale)
+	return f
+}
+
+// NewFormatterStrict creates a new formatter for the given locale,
+// returning an InvalidLocaleError if the locale has no format of its own.
+//
+// NewFormatter falls back to the locale's parents instead.
+// Note that formats are only stored for locales which differ from their
+// parent, so locales sharing their parent's format are rejected too,
+// e.g. "fr-BE" (which uses "fr") and "en-GB" (which uses "en").
+// "en" and "en-US" are considered equivalent.
+func NewFormatterStrict(locale Locale) (*Formatter, error) {
+	f := NewFormatter(locale)
+	l := locale
+	if l == (Locale{Language: "en", Territory: "US"}) {
+		l = Locale{Language: "en"}
+	}
+	if locale.IsEmpty() || f.resolvedLocale != l {
+		return nil, InvalidLocaleError{locale.String()}
+	}
+	return f, nil
+}
+
+// NewFormatterWithFallback creates a new formatter for the given locale,
+// using custom fallbacks for locales without their own format.
+//
+// The number format is resolved in the following order:
+//  1. The locale itself (e.g. "en-AU")
+//  2. Each of the fallbacks, in order (e.g. "en-IN")
+//  3. The locale's parents (e.g. "en-001", "en")
+//
+// Fallbacks are resolved via their parents of the same language
+// (e.g. "es-MX" uses the "es-419" format), but never via "en" for
+// other languages. Fallbacks without any data are skipped.
+// Currency symbols are still resolved from the locale and its parents.
+func NewFormatterWithFallback(locale Locale, fallbacks []Locale) *Formatter {
+	f := NewFormatter(locale)
+	f.format, f.resolvedLocale = getFormatWithFallback(locale, fallbacks)
 	return f
 }
 
@@ -81,15 +305,147 @@ func (f *Formatter) Locale() Locale {
 	return f.locale
 }
 
+// ResolvedLocale returns the locale whose number format is used.
+//
+// This is the requested locale or the closest ancestor (or fallback)
+// with its own format, e.g. "es-MX" resolves to "es-419". Useful for
+// debugging unexpected output. Note that currency symbols are resolved
+// separately, per currency.
+func (f *Formatter) ResolvedLocale() Locale {
+	return f.resolvedLocale
+}
+
+// Options returns the formatter settings.
+//
+// Used as a starting point for loading partial configuration,
+// so that settings missing from the configuration keep their defaults:
+//
+//	opts := formatter.Options()
+//	err := json.Unmarshal(config, &opts)
+//	formatter.ApplyOptions(opts)
+func (f *Formatter) Options() FormatOptions {
+	return FormatOptions{
+		NoGrouping:               f.NoGrouping,
+		AddPlusSign:              f.AddPlusSign,
+		NoDeltaArrows:            f.NoDeltaArrows,
+		MinDigits:                f.MinDigits,
+		MaxDigits:                f.MaxDigits,
+		HideZeroFraction:         f.HideZeroFraction,
+		MaxSignificantDigits:     f.MaxSignificantDigits,
+		SuperscriptFraction:      f.SuperscriptFraction,
+		RoundingMode:             f.RoundingMode,
+		CurrencyDisplay:          f.CurrencyDisplay,
+		PreserveSymbolSpace:      f.PreserveSymbolSpace,
+		SymbolMap:                copySymbolMap(f.SymbolMap),
+		LocaleSymbolMap:          copyLocaleSymbolMap(f.LocaleSymbolMap),
+		NegativeSymbolMap:        copySymbolMap(f.NegativeSymbolMap),
+		AmbiguousCurrencyDisplay: f.AmbiguousCurrencyDisplay,
+		AmbiguousCurrencies:      append([]string(nil), f.AmbiguousCurrencies...),
+		BidiMode:                 f.BidiMode,
+		ASCIIMinusSign:           f.ASCIIMinusSign,
+		NumberingSystem:          f.NumberingSystem,
+	}
+}
+
+// ApplyOptions replaces the formatter settings with the given ones.
+func (f *Formatter) ApplyOptions(opts FormatOptions) {
+	f.NoGrouping = opts.NoGrouping
+	f.AddPlusSign = opts.AddPlusSign
+	f.NoDeltaArrows = opts.NoDeltaArrows
+	f.MinDigits = opts.MinDigits
+	f.MaxDigits = opts.MaxDigits
+	f.HideZeroFraction = opts.HideZeroFraction
+	f.MaxSignificantDigits = opts.MaxSignificantDigits
+	f.SuperscriptFraction = opts.SuperscriptFraction
+	f.RoundingMode = opts.RoundingMode
+	f.CurrencyDisplay = opts.CurrencyDisplay
+	f.PreserveSymbolSpace = opts.PreserveSymbolSpace
+	f.SymbolMap = copySymbolMap(opts.SymbolMap)
+	f.LocaleSymbolMap = copyLocaleSymbolMap(opts.LocaleSymbolMap)
+	f.NegativeSymbolMap = copySymbolMap(opts.NegativeSymbolMap)
+	f.AmbiguousCurrencyDisplay = opts.AmbiguousCurrencyDisplay
+	f.AmbiguousCurrencies = append([]string(nil), opts.AmbiguousCurrencies...)
+	f.BidiMode = opts.BidiMode
+	f.ASCIIMinusSign = opts.ASCIIMinusSign
+	f.NumberingSystem = opts.NumberingSystem
+}
+
+// Equal returns whether f and other have equivalent settings.
+//
+// Maps and slices (SymbolMap, AmbiguousCurrencies, etc) are compared
+// by their contents. The locale format is compared too, which is implied
+// by an equal locale.
+func (f *Formatter) Equal(other *Formatter) bool {
+	if f == nil || other == nil {
+		return f == other
+	}
+	if f.locale != other.locale || f.format != other.format ||
+		f.NoGrouping != other.NoGrouping ||
+		f.AddPlusSign != other.AddPlusSign ||
+		f.NoDeltaArrows != other.NoDeltaArrows ||
+		f.MinDigits != other.MinDigits ||
+		f.MaxDigits != other.MaxDigits ||
+		f.HideZeroFraction != other.HideZeroFraction ||
+		f.MaxSignificantDigits != other.MaxSignificantDigits ||
+		f.SuperscriptFraction != other.SuperscriptFraction ||
+		f.RoundingMode != other.RoundingMode ||
+		f.CurrencyDisplay != other.CurrencyDisplay ||
+		f.PreserveSymbolSpace != other.PreserveSymbolSpace ||
+		f.AmbiguousCurrencyDisplay != other.AmbiguousCurrencyDisplay ||
+		f.BidiMode != other.BidiMode ||
+		f.ASCIIMinusSign != other.ASCIIMinusSign ||
+		f.NumberingSystem != other.NumberingSystem {
+		return false
+	}
+	if !equalSymbolMaps(f.SymbolMap, other.SymbolMap) ||
+		!equalSymbolMaps(f.NegativeSymbolMap, other.NegativeSymbolMap) {
+		return false
+	}
+	if len(f.LocaleSymbolMap) != len(other.LocaleSymbolMap) {
+		return false
+	}
+	for localeID, symbolMap := range f.LocaleSymbolMap {
+		otherSymbolMap, ok := other.LocaleSymbolMap[localeID]
+		if !ok || !equalSymbolMaps(symbolMap, otherSymbolMap) {
+			return false
+		}
+	}
+	if len(f.AmbiguousCurrencies) != len(other.AmbiguousCurrencies) {
+		return false
+	}
+	for i, currencyCode := range f.AmbiguousCurrencies {
+		if other.AmbiguousCurrencies[i] != currencyCode {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Format formats a currency amount.
 func (f *Formatter) Format(amount Amount) string {
 	pattern := f.getPattern(amount)
-	if amount.IsNegative() {
+	negative := amount.IsNegative()
+	if amount.number.Negative {
 		// The minus sign will be provided by the pattern.
+		// Negative zero ("-0") is formatted as zero.
 		amount, _ = amount.Mul("-1")
 	}
 	formattedNumber := f.formatNumber(amount)
-	formattedCurrency := f.formatCurrency(amount.CurrencyCode())
+	switch f.BidiMode {
+	case BidiIsolate:
+		formattedNumber = "\u2068" + formattedNumber + "\u2069"
+	case BidiEmbedding:
+		formattedNumber = "\u202a" + formattedNumber + "\u202c"
+	}
+	formattedCurrency := f.formatCurrency(amount.CurrencyCode(), negative)
+	preserveSpace := f.PreserveSymbolSpace && formattedCurrency == ""
+	if preserveSpace {
+		g := *f
+		g.CurrencyDisplay = DisplaySymbol
+		g.AmbiguousCurrencyDisplay = DisplaySymbol
+		formattedCurrency = g.formatCurrency(amount.CurrencyCode(), negative)
+	}
 	if formattedCurrency != "" {
 		// CLDR requires having a space between the letters
 		// in a currency symbol and adjacent numbers.
@@ -105,15 +461,15 @@ func (f *Formatter) Format(amount Amount) string {
 			}
 		}
 	}
+	if preserveSpace {
+		formattedCurrency = strings.Repeat(" ", utf8.RuneCountInString(formattedCurrency))
+	}
 
-	replacements := []string{
-		"0.00", formattedNumber,
-		"¤", formattedCurrency,
-		"+", f.format.plusSign,
-		"-", f.format.minusSign,
+	plusSign, minusSign := f.format.plusSign, f.format.minusSign
+	if f.ASCIIMinusSign {
+		plusSign, minusSign = "+", "-"
 	}
-	r := strings.NewReplacer(replacements...)
-	formattedAmount := r.Replace(pattern)
+	formattedAmount := expandPattern(pattern, formattedNumber, formattedCurrency, plusSign, minusSign)
 	if formattedCurrency == "" {
 		// Many patterns have a non-breaking space between
 		// the number and currency, not needed in this case.
@@ -123,33 +479,421 @@ func (f *Formatter) Format(amount Amount) string {
 	return formattedAmount
 }
 
+// FormatWithRounding formats a currency amount, also returning whether
+// the formatted value was rounded (due to MaxDigits or MaxSignificantDigits).
+//
+// Useful for marking rounded amounts on receipts, e.g. with an asterisk.
+// Removing trailing zeroes (1.50 => 1.5) is not considered rounding.
+func (f *Formatter) FormatWithRounding(amount Amount) (formatted string, rounded bool) {
+	r := f.round(amount)
+	return f.Format(amount), r.number.Cmp(&amount.number) != 0
+}
+
+// FormatSeparate formats a currency amount, returning the number and the
+// currency separately, e.g. for showing them in separate table columns.
+//
+// The number is formatted as if CurrencyDisplay was currency.DisplayNone,
+// while the currency is formatted as in Format.
+func (f *Formatter) FormatSeparate(amount Amount) FormattedAmount {
+	g := *f
+	g.CurrencyDisplay = DisplayNone
+	g.AmbiguousCurrencyDisplay = DisplayNone
+	g.PreserveSymbolSpace = false
+
+	return FormattedAmount{
+		Number:   g.Format(amount),
+		Currency: f.formatCurrency(amount.CurrencyCode(), amount.IsNegative()),
+	}
+}
+
+// FormatAbs formats the absolute value of a currency amount, and reports
+// whether the amount was negative, e.g. for showing the sign separately.
+//
+// The plus sign is never added, regardless of AddPlusSign.
+func (f *Formatter) FormatAbs(amount Amount) (formatted string, negative bool) {
+	negative = amount.IsNegative()
+	if negative {
+		amount, _ = amount.Mul("-1")
+	}
+	g := *f
+	g.AddPlusSign = false
+
+	return g.Format(amount), negative
+}
+
+// FormatTemplate formats a currency amount using a template with named
+// placeholders, e.g. "{sign}{symbol}{integer}{decimal}{fraction} ({code})".
+//
+// Recognized placeholders:
+//   - {sign}: the minus sign for negative amounts, or the plus sign
+//     for positive amounts if AddPlusSign is set
+//   - {symbol}: the currency symbol, regardless of CurrencyDisplay
+//   - {code}: the currency code
+//   - {integer}: the grouped integer digits
+//   - {decimal}: the decimal separator, if there are fraction digits
+//   - {fraction}: the fraction digits
+//
+// Other text is kept as-is. Returns an InvalidTemplateError for unknown
+// or unclosed placeholders. Currency names are not available, so {name}
+// is unknown as well.
+func (f *Formatter) FormatTemplate(amount Amount, tmpl string) (string, error) {
+	negative := amount.IsNegative()
+	if amount.number.Negative {
+		amount, _ = amount.Mul("-1")
+	}
+	plusSign, minusSign := f.format.plusSign, f.format.minusSign
+	if f.ASCIIMinusSign {
+		plusSign, minusSign = "+", "-"
+	}
+	sign := ""
+	if negative {
+		sign = minusSign
+	} else if f.AddPlusSign {
+		sign = plusSign
+	}
+	majorDigits, minorDigits := f.formatNumberParts(amount)
+	decimal := ""
+	if minorDigits != "" {
+		decimal = f.format.decimalSeparator
+	}
+	g := *f
+	g.CurrencyDisplay = DisplaySymbol
+	g.AmbiguousCurrencyDisplay = DisplaySymbol
+	placeholders := map[string]string{
+		"sign":     sign,
+		"symbol":   g.formatCurrency(amount.CurrencyCode(), negative),
+		"code":     amount.CurrencyCode(),
+		"integer":  f.localizeDigits(majorDigits),
+		"decimal":  decimal,
+		"fraction": f.localizeDigits(minorDigits),
+	}
+
+	b := strings.Builder{}
+	rest := tmpl
+	for {
+		start := strings.IndexByte(rest, '{')
+		if start == -1 {
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start:], '}')
+		if end == -1 {
+			return "", InvalidTemplateError{tmpl}
+		}
+		value, ok := placeholders[rest[start+1:start+end]]
+		if !ok {
+			return "", InvalidTemplateError{tmpl}
+		}
+		b.WriteString(rest[:start])
+		b.WriteString(value)
+		rest = rest[start+end+1:]
+	}
+
+	return b.String(), nil
+}
+
+// FormatDelta formats a currency amount as a change in value,
+// e.g. "▲+$10.00" for positive and "▼-$10.00" for negative amounts.
+//
+// The sign is always shown, placed according to the locale's pattern.
+// Zero amounts are shown as "—". The arrows are always placed in front,
+// since CLDR has no data for them. They are omitted if NoDeltaArrows
+// is set, in which case zero amounts are formatted normally, without a sign.
+//
+// Returns an InvalidCurrencyCodeError if the amount has no currency code
+// (e.g. a zero Amount{}).
+func (f *Formatter) FormatDelta(amount Amount) (string, error) {
+	if amount.CurrencyCode() == "" {
+		return "", InvalidCurrencyCodeError{amount.CurrencyCode()}
+	}
+	if amount.IsZero() {
+		if !f.NoDeltaArrows {
+			return "—", nil
+		}
+		return f.Format(amount), nil
+	}
+	g := *f
+	g.AddPlusSign = true
+	formatted := g.Format(amount)
+	if f.NoDeltaArrows {
+		return formatted, nil
+	}
+	if amount.IsNegative() {
+		return "▼" + formatted, nil
+	}
+	return "▲" + formatted, nil
+}
+
+// FormatScaledUnit formats a currency amount as a rate in the given unit,
+// in place of the currency, e.g. "12.5‰" or "125 bps" for 0.0125.
+//
+// Trailing zeroes are removed when MinDigits is currency.DefaultDigits,
+// since the currency's digits do not apply to the scaled number.
+//
+// The number uses the locale's digits and separators, but the loaded
+// CLDR data has no per-mille signs or percent patterns. The "‰" and "bps"
+// suffixes are therefore always used, without a space before "‰", and the
+// minus sign is always placed in front. This matches Latin-script locales
+// such as "en", but not e.g. "de" ("12,5 ‰") or "ar" ("؉").
+//
+// Returns an InvalidUnitError if the unit is not PerMille or BasisPoints.
+func (f *Formatter) FormatScaledUnit(amount Amount, unit ScaledUnit) (string, error) {
+	var scale, suffix string
+	switch unit {
+	case PerMille:
+		scale, suffix = "1000", "‰"
+	case BasisPoints:
+		scale, suffix = "10000", "\u00a0bps"
+	default:
+		return "", InvalidUnitError{strconv.Itoa(int(unit))}
+	}
+	g := *f
+	if g.MinDigits == DefaultDigits {
+		g.MinDigits = 0
+	}
+	negative := amount.IsNegative()
+	if amount.number.Negative {
+		amount, _ = amount.Mul("-1")
+	}
+	amount, _ = amount.Mul(scale)
+	formatted := g.formatNumber(amount) + suffix
+	if negative {
+		minusSign := f.format.minusSign
+		if f.ASCIIMinusSign {
+			minusSign = "-"
+		}
+		formatted = minusSign + formatted
+	}
+
+	return formatted, nil
+}
+
+// FormatUnitPrice formats a currency amount as a price per unit,
+// e.g. "$2.50/kg" for "en-US" and "2,50 €/kg" for "fr-FR".
+//
+// The unit is used as-is, it is up to the caller to localize it.
+// The separator is always "/", without spacing: the loaded CLDR data
+// has no per-unit patterns, so locales whose short "{0}/{1}" pattern
+// differs are not supported.
+//
+// Returns an InvalidUnitError if the unit is empty or only whitespace.
+func (f *Formatter) FormatUnitPrice(amount Amount, unit string) (string, error) {
+	if strings.TrimSpace(unit) == "" {
+		return "", InvalidUnitError{unit}
+	}
+	return f.Format(amount) + "/" + unit, nil
+}
+
+// FormatAs formats a numeric string as an amount in the given currency.
+//
+// Shortcut for creating an amount via NewAmount, then formatting it.
+func (f *Formatter) FormatAs(n, currencyCode string) (string, error) {
+	amount, err := NewAmount(n, currencyCode)
+	if err != nil {
+		return "", err
+	}
+	return f.Format(amount), nil
+}
+
+// FormatCurrency formats the currency for display, without an amount.
+//
+// Returns the symbol, the currency code, or an empty string,
+// depending on CurrencyDisplay.
+func (f *Formatter) FormatCurrency(currencyCode string) string {
+	return f.formatCurrency(currencyCode, false)
+}
+
+// formatCurrency formats the currency for display, using
+// NegativeSymbolMap if the amount is negative.
+func (f *Formatter) formatCurrency(currencyCode string, negative bool) string {
+	currencyCode = canonicalCode(currencyCode)
+	var formatted string
+	currencyDisplay := f.CurrencyDisplay
+	if currencyDisplay == DisplaySymbol && f.isAmbiguous(currencyCode) {
+		currencyDisplay = f.AmbiguousCurrencyDisplay
+	}
+	switch currencyDisplay {
+	case DisplaySymbol:
+		if symbol, ok := f.NegativeSymbolMap[currencyCode]; ok && negative {
+			formatted = symbol
+		} else if symbol, ok := f.getLocaleSymbol(currencyCode); ok {
+			formatted = symbol
+		} else if symbol, ok := f.SymbolMap[currencyCode]; ok {
+			formatted = symbol
+		} else {
+			formatted, _ = GetSymbol(currencyCode, f.locale)
+		}
+	case DisplayCode:
+		formatted = currencyCode
+	default:
+		formatted = ""
+	}
+
+	return formatted
+}
+
 // Parse parses a formatted amount.
+//
+// Negative amounts can be indicated either by the minus sign,
+// or by surrounding parentheses, e.g. "($1,234.56)".
+//
+// Separators are interpreted strictly according to the locale, e.g.
+// "1,234" is 1234 in "en", but 1.234 in "de". Returns an InvalidNumberError
+// if the decimal separator appears more than once, or if the grouping
+// separator is not placed according to the locale's grouping rules.
+//
+// Digits from more than one numbering system (e.g. "١٢34") are rejected
+// with an InvalidNumberError, since such input is almost always malformed.
 func (f *Formatter) Parse(s, currencyCode string) (Amount, error) {
+	// "_" is used below to mark whitespace, and is never valid input.
+	if hasMixedDigits(s) || strings.Contains(s, "_") {
+		return Amount{}, InvalidNumberError{s}
+	}
+	currencyCode = canonicalCode(currencyCode)
 	symbol, _ := GetSymbol(currencyCode, f.locale)
+	// Whitespace grouping separators are marked separately, to allow
+	// distinguishing them from whitespace around the currency.
+	groupingReplacement := ","
+	spaceReplacement := ""
+	if isSpace(f.format.groupingSeparator) {
+		groupingReplacement = ""
+		if f.format.primaryGroupingSize > 0 {
+			groupingReplacement = "_"
+			spaceReplacement = "_"
+		}
+	}
 	replacements := []string{
 		f.format.decimalSeparator, ".",
-		f.format.groupingSeparator, "",
+		f.format.groupingSeparator, groupingReplacement,
 		f.format.plusSign, "+",
 		f.format.minusSign, "-",
 		symbol, "",
 		currencyCode, "",
 		"\u200e", "",
 		"\u200f", "",
-		"\u00a0", "",
-		" ", "",
+		"\u2068", "",
+		"\u2069", "",
+		"\u202a", "",
+		"\u202c", "",
+		"\u00a0", spaceReplacement,
+		"\u202f", spaceReplacement,
+		" ", spaceReplacement,
 	}
-	if f.format.numberingSystem != numLatn {
-		digits := localDigits[f.format.numberingSystem]
+	if numSystem := f.getNumberingSystem(); numSystem != numLatn {
+		digits := localDigits[numSystem]
 		for i, v := range strings.Split(digits, "") {
 			replacements = append(replacements, v, strconv.Itoa(i))
 		}
 	}
 	r := strings.NewReplacer(replacements...)
-	n := r.Replace(s)
+	n := normalizeSpaces(r.Replace(s))
+	if strings.HasPrefix(n, "(") && strings.HasSuffix(n, ")") {
+		n = strings.TrimSuffix(strings.TrimPrefix(n, "("), ")")
+		if strings.ContainsAny(n, "+-") {
+			return Amount{}, InvalidNumberError{s}
+		}
+		n = "-" + n
+	}
+	if !f.isValidGrouping(n) {
+		return Amount{}, InvalidNumberError{s}
+	}
+	n = strings.ReplaceAll(n, ",", "")
 
 	return NewAmount(n, currencyCode)
 }
 
+// ParsePercent parses a localized percentage into a numeric string.
+//
+// The percent sign and any whitespace before it are optional, e.g.
+// "20,5 %", "20,5%" and "20,5" are all parsed as "20.5" for "de".
+// Useful for passing user-entered rates to Amount.AddPercent.
+// Like Formatter.Parse, rejects digits from more than one numbering system.
+// Numbers in the exponent notation ("1e3") are rejected, and the result
+// never uses it ("0.0000001" stays "0.0000001").
+func ParsePercent(s string, locale Locale) (string, error) {
+	if hasMixedDigits(s) {
+		return "", InvalidNumberError{s}
+	}
+	format, _ := getFormat(locale)
+	n := strings.TrimSpace(s)
+	n = strings.TrimSuffix(n, "%")
+	n = strings.TrimSuffix(n, "٪")
+	n = strings.TrimRightFunc(n, unicode.IsSpace)
+	if strings.Contains(n, format.groupingSeparator) {
+		return "", InvalidNumberError{s}
+	}
+	replacements := []string{
+		format.decimalSeparator, ".",
+		format.plusSign, "+",
+		format.minusSign, "-",
+		"\u200e", "",
+		"\u200f", "",
+		"\u061c", "",
+	}
+	if format.numberingSystem != numLatn {
+		digits := localDigits[format.numberingSystem]
+		for i, v := range strings.Split(digits, "") {
+			replacements = append(replacements, v, strconv.Itoa(i))
+		}
+	}
+	r := strings.NewReplacer(replacements...)
+	n = r.Replace(n)
+	// Localized input never uses the exponent notation.
+	if strings.ContainsAny(n, "eE") {
+		return "", InvalidNumberError{s}
+	}
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(n); err != nil || number.Form != apd.Finite {
+		return "", InvalidNumberError{s}
+	}
+
+	return number.Text('f'), nil
+}
+
+// isValidGrouping returns whether the grouping in the number n
+// matches the currency format.
+//
+// The number is expected to use "." as the decimal separator and
+// "," as the grouping separator. Numbers without grouping are valid.
+func (f *Formatter) isValidGrouping(n string) bool {
+	numberParts := strings.Split(n, ".")
+	if len(numberParts) > 2 {
+		return false
+	}
+	if len(numberParts) == 2 && strings.Contains(numberParts[1], ",") {
+		return false
+	}
+	majorDigits := strings.TrimLeft(numberParts[0], "+-")
+	if !strings.Contains(majorDigits, ",") {
+		return true
+	}
+	primarySize := int(f.format.primaryGroupingSize)
+	secondarySize := int(f.format.secondaryGroupingSize)
+	if primarySize == 0 {
+		return false
+	}
+	groups := strings.Split(majorDigits, ",")
+	lastGroup := len(groups) - 1
+	for i, group := range groups {
+		switch {
+		case i == lastGroup:
+			if len(group) != primarySize {
+				return false
+			}
+		case i == 0:
+			if len(group) == 0 || len(group) > secondarySize {
+				return false
+			}
+		default:
+			if len(group) != secondarySize {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
 // getPattern returns a positive or negative pattern for a currency amount.
 func (f *Formatter) getPattern(amount Amount) string {
 	patterns := strings.Split(f.format.pattern, ";")
@@ -167,20 +911,85 @@ func (f *Formatter) getPattern(amount Amount) string {
 	return patterns[0]
 }
 
+// expandPattern replaces the placeholders in the pattern.
+//
+// The pattern is tokenized, so literal text is always preserved.
+// Supported placeholders: "0.00" (number), "¤" (currency), "+" (plus sign),
+// "-" (minus sign). Repeated currency placeholders ("¤¤") are treated
+// as one. Text inside single quotes is literal, and two single quotes
+// represent a single quote, as specified by CLDR.
+func expandPattern(pattern, number, currency, plusSign, minusSign string) string {
+	b := strings.Builder{}
+	for i := 0; i < len(pattern); {
+		switch {
+		case strings.HasPrefix(pattern[i:], "0.00"):
+			b.WriteString(number)
+			i += len("0.00")
+		case strings.HasPrefix(pattern[i:], "¤"):
+			b.WriteString(currency)
+			for strings.HasPrefix(pattern[i:], "¤") {
+				i += len("¤")
+			}
+		case pattern[i] == '+':
+			b.WriteString(plusSign)
+			i++
+		case pattern[i] == '-':
+			b.WriteString(minusSign)
+			i++
+		case pattern[i] == '\'':
+			if strings.HasPrefix(pattern[i:], "''") {
+				b.WriteByte('\'')
+				i += 2
+				continue
+			}
+			// Quoted literal text, an unterminated quote runs until the end.
+			for i++; i < len(pattern); i++ {
+				if strings.HasPrefix(pattern[i:], "''") {
+					b.WriteByte('\'')
+					i++
+				} else if pattern[i] == '\'' {
+					i++
+					break
+				} else {
+					b.WriteByte(pattern[i])
+				}
+			}
+		default:
+			b.WriteByte(pattern[i])
+			i++
+		}
+	}
+
+	return b.String()
+}
+
 // formatNumber formats the number for display.
 func (f *Formatter) formatNumber(amount Amount) string {
-	minDigits := f.MinDigits
-	if minDigits == DefaultDigits {
-		minDigits, _ = GetDigits(amount.CurrencyCode())
-	}
-	maxDigits := f.MaxDigits
-	if maxDigits == DefaultDigits {
-		maxDigits, _ = GetDigits(amount.CurrencyCode())
+	majorDigits, minorDigits := f.formatNumberParts(amount)
+	b := strings.Builder{}
+	b.WriteString(majorDigits)
+	if minorDigits != "" {
+		if f.SuperscriptFraction {
+			b.WriteString(superscriptDigits.Replace(minorDigits))
+		} else {
+			b.WriteString(f.format.decimalSeparator)
+			b.WriteString(minorDigits)
+		}
 	}
-	amount = amount.RoundTo(maxDigits, f.RoundingMode)
-	numberParts := strings.Split(amount.Number(), ".")
-	majorDigits := f.groupMajorDigits(numberParts[0])
-	minorDigits := ""
+	formatted := f.localizeDigits(b.String())
+
+	return formatted
+}
+
+// formatNumberParts rounds the number and returns its grouped major digits
+// and its minor digits, without localizing them.
+func (f *Formatter) formatNumberParts(amount Amount) (majorDigits, minorDigits string) {
+	minDigits, maxDigits := f.getDigits(amount)
+	amount = f.round(amount)
+	// Numbers too large to be rounded (e.g. "1E+100000") keep their
+	// exponent, use the plain notation to get all of their digits.
+	numberParts := strings.Split(amount.number.Text('f'), ".")
+	majorDigits = f.groupMajorDigits(numberParts[0])
 	if len(numberParts) == 2 {
 		minorDigits = numberParts[1]
 	}
@@ -193,34 +1002,70 @@ func (f *Formatter) formatNumber(amount Amount) string {
 			minorDigits += strings.Repeat("0", int(minDigits)-len(minorDigits))
 		}
 	}
-	b := strings.Builder{}
-	b.WriteString(majorDigits)
-	if minorDigits != "" {
-		b.WriteString(f.format.decimalSeparator)
-		b.WriteString(minorDigits)
+	if f.HideZeroFraction && strings.Trim(minorDigits, "0") == "" {
+		minorDigits = ""
 	}
-	formatted := f.localizeDigits(b.String())
 
-	return formatted
+	return majorDigits, minorDigits
 }
 
-// formatCurrency formats the currency for display.
-func (f *Formatter) formatCurrency(currencyCode string) string {
-	var formatted string
-	switch f.CurrencyDisplay {
-	case DisplaySymbol:
-		if symbol, ok := f.SymbolMap[currencyCode]; ok {
-			formatted = symbol
-		} else {
-			formatted, _ = GetSymbol(currencyCode, f.locale)
+// getDigits returns the minimum and maximum number of fraction digits
+// for the given amount, resolving currency.DefaultDigits.
+func (f *Formatter) getDigits(amount Amount) (minDigits, maxDigits uint8) {
+	minDigits = f.MinDigits
+	if minDigits == DefaultDigits {
+		minDigits, _ = GetDigits(amount.CurrencyCode())
+	}
+	maxDigits = f.MaxDigits
+	if maxDigits == DefaultDigits {
+		maxDigits, _ = GetDigits(amount.CurrencyCode())
+	}
+	if f.MaxDigits == defaultMaxDigits && maxDigits < minDigits {
+		// Currencies with many digits (e.g. BTC) must not be truncated,
+		// unless a lower MaxDigits was requested explicitly.
+		maxDigits = minDigits
+	}
+	return minDigits, maxDigits
+}
+
+// round rounds the amount for display, as specified by
+// MaxSignificantDigits, MaxDigits and RoundingMode.
+func (f *Formatter) round(amount Amount) Amount {
+	_, maxDigits := f.getDigits(amount)
+	amount = amount.roundSignificant(f.MaxSignificantDigits, f.RoundingMode)
+	return amount.RoundTo(maxDigits, f.RoundingMode)
+}
+
+// getLocaleSymbol returns the custom symbol for the formatter's locale.
+//
+// Falls back to the symbols of the locale's parents.
+func (f *Formatter) getLocaleSymbol(currencyCode string) (symbol string, ok bool) {
+	if len(f.LocaleSymbolMap) == 0 {
+		return "", false
+	}
+	locale := f.locale
+	for !locale.IsEmpty() {
+		if symbol, ok := f.LocaleSymbolMap[locale.String()][currencyCode]; ok {
+			return symbol, true
+		}
+		locale = locale.GetParent()
+		// Every locale eventually falls back to "en", whose custom
+		// symbols should only apply to English locales.
+		if locale.String() == "en" && f.locale.Language != "en" {
+			break
 		}
-	case DisplayCode:
-		formatted = currencyCode
-	default:
-		formatted = ""
 	}
+	return "", false
+}
 
-	return formatted
+// isAmbiguous returns whether the currency is listed in AmbiguousCurrencies.
+func (f *Formatter) isAmbiguous(currencyCode string) bool {
+	for _, v := range f.AmbiguousCurrencies {
+		if v == currencyCode {
+			return true
+		}
+	}
+	return false
 }
 
 // groupMajorDigits groups major digits according to the currency format.
@@ -236,32 +1081,42 @@ func (f *Formatter) groupMajorDigits(majorDigits string) string {
 		return majorDigits
 	}
 
-	// Digits are grouped from right to left.
-	// First the primary group, then the secondary groups.
-	var groups []string
-	groups = append(groups, majorDigits[numDigits-primarySize:numDigits])
-	for i := numDigits - primarySize; i > 0; i = i - secondarySize {
-		low := i - secondarySize
-		if low < 0 {
-			low = 0
-		}
-		groups = append(groups, majorDigits[low:i])
+	if secondarySize == 0 {
+		secondarySize = primarySize
+	}
+
+	// The primary group is the rightmost one, preceded by the secondary
+	// groups. The leftmost secondary group can be shorter than the rest.
+	primaryStart := numDigits - primarySize
+	if primaryStart <= 0 {
+		return majorDigits
 	}
-	// Reverse the groups and reconstruct the digits.
-	for i, j := 0, len(groups)-1; i < j; i, j = i+1, j-1 {
-		groups[i], groups[j] = groups[j], groups[i]
+	groupEnd := primaryStart % secondarySize
+	if groupEnd == 0 {
+		groupEnd = secondarySize
 	}
-	majorDigits = strings.Join(groups, f.format.groupingSeparator)
+	separator := f.format.groupingSeparator
+	numGroups := 1 + (primaryStart+secondarySize-1)/secondarySize
+	b := strings.Builder{}
+	b.Grow(numDigits + (numGroups-1)*len(separator))
+	b.WriteString(majorDigits[:groupEnd])
+	for i := groupEnd; i < primaryStart; i += secondarySize {
+		b.WriteString(separator)
+		b.WriteString(majorDigits[i : i+secondarySize])
+	}
+	b.WriteString(separator)
+	b.WriteString(majorDigits[primaryStart:])
 
-	return majorDigits
+	return b.String()
 }
 
 // localizeDigits replaces digits with their localized equivalents.
 func (f *Formatter) localizeDigits(number string) string {
-	if f.format.numberingSystem == numLatn {
+	numSystem := f.getNumberingSystem()
+	if numSystem == numLatn {
 		return number
 	}
-	digits := localDigits[f.format.numberingSystem]
+	digits := localDigits[numSystem]
 	replacements := make([]string, 0, 20)
 	for i, v := range strings.Split(digits, "") {
 		replacements = append(replacements, strconv.Itoa(i), v)
@@ -271,3 +1126,108 @@ func (f *Formatter) localizeDigits(number string) string {
 
 	return number
 }
+
+// hasMixedDigits returns whether s contains digits from more than one
+// numbering system.
+func hasMixedDigits(s string) bool {
+	found := false
+	var first numberingSystem
+	for _, r := range s {
+		numSystem, ok := getDigitNumberingSystem(r)
+		if !ok {
+			continue
+		}
+		if !found {
+			first, found = numSystem, true
+		} else if numSystem != first {
+			return true
+		}
+	}
+	return false
+}
+
+// getDigitNumberingSystem returns the numbering system of the digit r.
+func getDigitNumberingSystem(r rune) (numberingSystem, bool) {
+	if r >= '0' && r <= '9' {
+		return numLatn, true
+	}
+	if r < utf8.RuneSelf {
+		return numLatn, false
+	}
+	for numSystem, digits := range localDigits {
+		if strings.ContainsRune(digits, r) {
+			return numSystem, true
+		}
+	}
+	return numLatn, false
+}
+
+// getNumberingSystem returns the numbering system used for digits.
+//
+// Unknown NumberingSystem values are ignored.
+func (f *Formatter) getNumberingSystem() numberingSystem {
+	if numSystem, ok := numberingSystemNames[f.NumberingSystem]; ok {
+		return numSystem
+	}
+	return f.format.numberingSystem
+}
+
+// copySymbolMap returns a copy of the given symbol map.
+func copySymbolMap(symbolMap map[string]string) map[string]string {
+	result := make(map[string]string, len(symbolMap))
+	for currencyCode, symbol := range symbolMap {
+		result[currencyCode] = symbol
+	}
+	return result
+}
+
+// copyLocaleSymbolMap returns a copy of the given locale symbol map.
+func copyLocaleSymbolMap(localeSymbolMap map[string]map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(localeSymbolMap))
+	for localeID, symbolMap := range localeSymbolMap {
+		result[localeID] = copySymbolMap(symbolMap)
+	}
+	return result
+}
+
+// equalSymbolMaps returns whether a and b have the same symbols.
+func equalSymbolMaps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for currencyCode, symbol := range a {
+		if otherSymbol, ok := b[currencyCode]; !ok || otherSymbol != symbol {
+			return false
+		}
+	}
+	return true
+}
+
+// isSpace returns whether s consists of a single whitespace character.
+func isSpace(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	return size > 0 && size == len(s) && unicode.IsSpace(r)
+}
+
+// normalizeSpaces converts whitespace markers ("_") between digits
+// into grouping separators (","), and removes all others.
+func normalizeSpaces(n string) string {
+	if !strings.Contains(n, "_") {
+		return n
+	}
+	isDigit := func(b byte) bool {
+		return b >= '0' && b <= '9'
+	}
+	b := strings.Builder{}
+	for i := 0; i < len(n); i++ {
+		if n[i] != '_' {
+			b.WriteByte(n[i])
+			continue
+		}
+		if i > 0 && i < len(n)-1 && isDigit(n[i-1]) && isDigit(n[i+1]) {
+			b.WriteByte(',')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+import "testing"
+
+func TestExpandPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"¤0.00", "$1,234.50"},
+		{"-¤0.00", "−$1,234.50"},
+		{"¤ 0.00;¤-0.00", "$ 1,234.50;$−1,234.50"},
+		{"Total: ¤0.00 only", "Total: $1,234.50 only"},
+		{"¤¤0.00", "$1,234.50"},
+		// Quoted literals are preserved.
+		{"'0.00 ¤+-' ¤0.00", "0.00 ¤+- $1,234.50"},
+		{"¤0.00 'o''clock'", "$1,234.50 o'clock"},
+		{"''¤0.00''", "'$1,234.50'"},
+		{"¤0.00 'unterminated -", "$1,234.50 unterminated -"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			got := expandPattern(tt.pattern, "1,234.50", "$", "＋", "−")
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_LiteralPattern(t *testing.T) {
+	formatter := NewFormatter(NewLocale("en"))
+	formatter.format.pattern = "Total: ¤0.00 only"
+	amount, _ := NewAmount("1234.5", "USD")
+	got := formatter.Format(amount)
+	if got != "Total: $1,234.50 only" {
+		t.Errorf("got %q, want %q", got, "Total: $1,234.50 only")
+	}
+	amount, _ = NewAmount("-1234.5", "USD")
+	got = formatter.Format(amount)
+	if got != "-Total: $1,234.50 only" {
+		t.Errorf("got %q, want %q", got, "-Total: $1,234.50 only")
+	}
+}
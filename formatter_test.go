@@ -4,7 +4,11 @@
 package currency_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/bojanz/currency"
 )
@@ -18,6 +22,231 @@ func TestFormatter_Locale(t *testing.T) {
 	}
 }
 
+func TestFormatter_ResolvedLocale(t *testing.T) {
+	tests := []struct {
+		localeID string
+		want     string
+	}{
+		{"de-CH", "de-CH"},
+		{"es-MX", "es-419"},
+		{"sr-Latn-BA", "sr-Latn"},
+		{"en-AU", "en"},
+		{"en-US", "en"},
+		{"xx", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.ResolvedLocale().String()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if formatter.Locale() != locale {
+				t.Errorf("got %v, want %v", formatter.Locale(), locale)
+			}
+		})
+	}
+}
+
+func TestNewFormatterStrict(t *testing.T) {
+	// Locales without a format of their own, including ones which
+	// share their parent's format (fr-BE => fr, en-GB => en).
+	for _, id := range []string{"xx", "xx-US", "tlh", "", "fr-BE", "en-GB", "de-XX", "en-ZZ"} {
+		t.Run(id, func(t *testing.T) {
+			formatter, err := currency.NewFormatterStrict(currency.NewLocale(id))
+			if formatter != nil {
+				t.Errorf("got %v, want nil", formatter)
+			}
+			if e, ok := err.(currency.InvalidLocaleError); ok {
+				if e.ID != currency.NewLocale(id).String() {
+					t.Errorf("got %v, want %v", e.ID, id)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidLocaleError", err)
+			}
+		})
+	}
+
+	for _, id := range []string{"en", "en-US", "fr", "de-CH", "es-419", "sr-Latn"} {
+		t.Run(id, func(t *testing.T) {
+			locale := currency.NewLocale(id)
+			formatter, err := currency.NewFormatterStrict(locale)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !formatter.Equal(currency.NewFormatter(locale)) {
+				t.Errorf("expected the formatter to match NewFormatter")
+			}
+		})
+	}
+}
+
+func TestNewFormatterWithFallback(t *testing.T) {
+	tests := []struct {
+		localeID    string
+		fallbackIDs []string
+		want        string
+	}{
+		// en-AU has no format of its own, and falls back to en-IN.
+		{"en-AU", []string{"en-IN"}, "$12,34,567.89"},
+		// Fallbacks are resolved via their parents, en-GB uses en.
+		{"en-AU", []string{"en-GB", "en-IN"}, "$1,234,567.89"},
+		{"en-AU", []string{"de-AT"}, "$\u00a01.234.567,89"},
+		// Fallbacks without any data are skipped.
+		{"en-AU", []string{"tlh", "en-IN"}, "$12,34,567.89"},
+		// No matching fallback, the parents are used.
+		{"en-AU", []string{"tlh"}, "$1,234,567.89"},
+		{"en-AU", nil, "$1,234,567.89"},
+		// The locale's own format takes precedence.
+		{"de-CH", []string{"en-IN"}, "AU$\u00a01’234’567.89"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			fallbacks := make([]currency.Locale, 0, len(tt.fallbackIDs))
+			for _, id := range tt.fallbackIDs {
+				fallbacks = append(fallbacks, currency.NewLocale(id))
+			}
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatterWithFallback(locale, fallbacks)
+			if formatter.Locale() != locale {
+				t.Errorf("got %v, want %v", formatter.Locale(), locale)
+			}
+			amount, _ := currency.NewAmount("1234567.89", "AUD")
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	resolvedTests := []struct {
+		fallbackID string
+		want       string
+	}{
+		{"en-IN", "en-IN"},
+		{"en-GB", "en"},
+		{"es-MX", "es-419"},
+		{"tlh", "en"},
+	}
+	for _, tt := range resolvedTests {
+		t.Run(tt.fallbackID, func(t *testing.T) {
+			locale := currency.NewLocale("en-AU")
+			formatter := currency.NewFormatterWithFallback(locale, []currency.Locale{currency.NewLocale(tt.fallbackID)})
+			got := formatter.ResolvedLocale().String()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_Equal(t *testing.T) {
+	newFormatter := func(localeID string) *currency.Formatter {
+		formatter := currency.NewFormatter(currency.NewLocale(localeID))
+		formatter.MaxDigits = 2
+		formatter.SymbolMap["USD"] = "US$"
+		formatter.LocaleSymbolMap["fr"] = map[string]string{"EUR": "EUR€"}
+		return formatter
+	}
+	a := newFormatter("de-CH")
+	b := newFormatter("de-CH")
+	if !a.Equal(b) || !b.Equal(a) {
+		t.Errorf("expected formatters to be equal")
+	}
+	// Nil and empty maps are equivalent.
+	c := newFormatter("de-CH")
+	delete(c.SymbolMap, "USD")
+	d := newFormatter("de-CH")
+	d.SymbolMap = nil
+	delete(c.LocaleSymbolMap, "fr")
+	d.LocaleSymbolMap = nil
+	if !c.Equal(d) {
+		t.Errorf("expected formatters to be equal")
+	}
+	var nilFormatter *currency.Formatter
+	if !nilFormatter.Equal(nil) {
+		t.Errorf("expected nil formatters to be equal")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(f *currency.Formatter)
+	}{
+		{"locale", func(f *currency.Formatter) { *f = *newFormatter("de") }},
+		{"MaxDigits", func(f *currency.Formatter) { f.MaxDigits = 3 }},
+		{"CurrencyDisplay", func(f *currency.Formatter) { f.CurrencyDisplay = currency.DisplayCode }},
+		{"SymbolMap value", func(f *currency.Formatter) { f.SymbolMap["USD"] = "$" }},
+		{"SymbolMap key", func(f *currency.Formatter) { f.SymbolMap["CAD"] = "CA$" }},
+		{"LocaleSymbolMap", func(f *currency.Formatter) { f.LocaleSymbolMap["fr"]["EUR"] = "€" }},
+		{"NegativeSymbolMap", func(f *currency.Formatter) { f.NegativeSymbolMap["USD"] = "CR $" }},
+		{"AmbiguousCurrencies", func(f *currency.Formatter) { f.AmbiguousCurrencies = []string{"USD"} }},
+		{"NumberingSystem", func(f *currency.Formatter) { f.NumberingSystem = "arab" }},
+		{"BidiMode", func(f *currency.Formatter) { f.BidiMode = currency.BidiEmbedding }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := newFormatter("de-CH")
+			tt.modify(other)
+			if a.Equal(other) || other.Equal(a) {
+				t.Errorf("expected formatters to not be equal")
+			}
+			if a.Equal(nil) {
+				t.Errorf("expected formatter to not equal nil")
+			}
+		})
+	}
+}
+
+func TestFormatter_Options(t *testing.T) {
+	locale := currency.NewLocale("de-CH")
+	a := currency.NewFormatter(locale)
+	a.NoGrouping = true
+	a.MaxDigits = 2
+	a.RoundingMode = currency.RoundHalfEven
+	a.CurrencyDisplay = currency.DisplayCode
+	a.SymbolMap["USD"] = "US$"
+	a.LocaleSymbolMap["fr"] = map[string]string{"EUR": "EUR€"}
+	a.NegativeSymbolMap["USD"] = "CR $"
+	a.BidiMode = currency.BidiIsolate
+	a.NumberingSystem = "arab"
+
+	config, err := json.Marshal(a.Options())
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	b := currency.NewFormatter(locale)
+	opts := b.Options()
+	if err := json.Unmarshal(config, &opts); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	b.ApplyOptions(opts)
+	if !a.Equal(b) {
+		t.Errorf("expected formatters to be equal, got %+v", b.Options())
+	}
+	// Confirm that the maps are not shared.
+	opts.SymbolMap["USD"] = "$"
+	opts.LocaleSymbolMap["fr"]["EUR"] = "€"
+	if !a.Equal(b) {
+		t.Errorf("expected formatters to be equal after modifying options")
+	}
+
+	// Settings missing from the config keep their defaults.
+	c := currency.NewFormatter(currency.NewLocale("en"))
+	opts = c.Options()
+	if err := json.Unmarshal([]byte(`{"max_digits": 2, "add_plus_sign": true}`), &opts); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	c.ApplyOptions(opts)
+	amount, _ := currency.NewAmount("1.005", "USD")
+	got := c.Format(amount)
+	if got != "+$1.01" {
+		t.Errorf("got %v, want +$1.01", got)
+	}
+}
+
 func TestFormatter_Format(t *testing.T) {
 	tests := []struct {
 		number       string
@@ -39,20 +268,955 @@ func TestFormatter_Format(t *testing.T) {
 		{"1234.00", "EUR", "de-CH", "€\u00a01’234.00"},
 		{"1234.00", "EUR", "sr", "1.234,00\u00a0€"},
 
-		{"1234.00", "CHF", "en", "CHF\u00a01,234.00"},
-		{"1234.00", "CHF", "de-CH", "CHF\u00a01’234.00"},
-		{"1234.00", "CHF", "sr", "1.234,00\u00a0CHF"},
+		{"1234.00", "CHF", "en", "CHF\u00a01,234.00"},
+		{"1234.00", "CHF", "de-CH", "CHF\u00a01’234.00"},
+		{"1234.00", "CHF", "sr", "1.234,00\u00a0CHF"},
+
+		// Arabic digits.
+		{"12345678.90", "USD", "ar", "١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
+		// Arabic extended (Persian) digits.
+		{"12345678.90", "USD", "fa", "\u200e$۱۲٬۳۴۵٬۶۷۸٫۹۰"},
+		// Bengali digits.
+		{"12345678.90", "USD", "bn", "১,২৩,৪৫,৬৭৮.৯০\u00a0US$"},
+		// Devanagari digits.
+		{"12345678.90", "USD", "ne", "US$\u00a0१,२३,४५,६७८.९०"},
+		// Myanmar (Burmese) digits.
+		{"12345678.90", "USD", "my", "၁၂,၃၄၅,၆၇၈.၉၀\u00a0US$"},
+
+		// Negative zero.
+		{"-0", "USD", "en", "$0.00"},
+		// Numbers larger than the default precision.
+		{"1E+40", "USD", "en", "$10,000,000,000,000,000,000,000,000,000,000,000,000,000.00"},
+		{"-99999999999999999999999999999999999999.999", "USD", "en", "-$100,000,000,000,000,000,000,000,000,000,000,000,000.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Numbers too large to be rounded are shown in full, without an exponent.
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	for _, number := range []string{"1E+100000", "-1E+100000"} {
+		amount, _ := currency.NewAmount(number, "USD")
+		got := formatter.Format(amount)
+		wantPrefix := "$10,000,000,"
+		if amount.IsNegative() {
+			wantPrefix = "-" + wantPrefix
+		}
+		if !strings.HasPrefix(got, wantPrefix) || !strings.HasSuffix(got, ",000.00") || strings.Contains(got, "E") {
+			t.Errorf("got %.20v...%v, want %v...,000.00", got, got[len(got)-10:], wantPrefix)
+		}
+		// "$", 100001 digits, 33333 grouping separators and ".00".
+		want := 1 + 100001 + 33333 + 3
+		if amount.IsNegative() {
+			want++
+		}
+		if len(got) != want {
+			t.Errorf("got %v characters, want %v", len(got), want)
+		}
+	}
+}
+
+func TestFormatter_FormatAs(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	_, err := formatter.FormatAs("INVALID", "USD")
+	if _, ok := err.(currency.InvalidNumberError); !ok {
+		t.Errorf("got %T, want currency.InvalidNumberError", err)
+	}
+	_, err = formatter.FormatAs("1234.5", "xyz")
+	if _, ok := err.(currency.InvalidCurrencyCodeError); !ok {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		maxDigits    uint8
+		want         string
+	}{
+		{"1234.5", "USD", 6, "$1,234.50"},
+		{"1234.5", "JPY", 6, "¥1,234.5"},
+		{"1234.5", "USD", currency.DefaultDigits, "$1,234.50"},
+		{"1234.5", "JPY", currency.DefaultDigits, "¥1,235"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			formatter.MaxDigits = tt.maxDigits
+			got, err := formatter.FormatAs(tt.number, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatSeparate(t *testing.T) {
+	tests := []struct {
+		number          string
+		currencyCode    string
+		localeID        string
+		currencyDisplay currency.Display
+		want            currency.FormattedAmount
+	}{
+		{"1234.59", "USD", "en-US", currency.DisplaySymbol, currency.FormattedAmount{"1,234.59", "$"}},
+		{"-1234.59", "USD", "en-US", currency.DisplaySymbol, currency.FormattedAmount{"-1,234.59", "$"}},
+		{"1234.59", "USD", "en-US", currency.DisplayCode, currency.FormattedAmount{"1,234.59", "USD"}},
+		{"1234.59", "EUR", "de-DE", currency.DisplaySymbol, currency.FormattedAmount{"1.234,59", "€"}},
+		{"-1234.59", "EUR", "de-DE", currency.DisplaySymbol, currency.FormattedAmount{"-1.234,59", "€"}},
+		{"1234.59", "EUR", "de-DE", currency.DisplayCode, currency.FormattedAmount{"1.234,59", "EUR"}},
+		{"1234.59", "EUR", "de-DE", currency.DisplayNone, currency.FormattedAmount{"1.234,59", ""}},
+		{"-1234.59", "USD", "de-CH", currency.DisplaySymbol, currency.FormattedAmount{"-1’234.59", "$"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale(tt.localeID))
+			formatter.CurrencyDisplay = tt.currencyDisplay
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got := formatter.FormatSeparate(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatAbs(t *testing.T) {
+	tests := []struct {
+		number       string
+		localeID     string
+		addPlusSign  bool
+		want         string
+		wantNegative bool
+	}{
+		{"-1234.59", "en-US", false, "$1,234.59", true},
+		{"1234.59", "en-US", false, "$1,234.59", false},
+		{"1234.59", "en-US", true, "$1,234.59", false},
+		{"-1234.59", "de-DE", false, "1.234,59 $", true},
+		{"-0", "en-US", false, "$0.00", false},
+		{"0", "en-US", true, "$0.00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale(tt.localeID))
+			formatter.AddPlusSign = tt.addPlusSign
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			got, negative := formatter.FormatAbs(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if negative != tt.wantNegative {
+				t.Errorf("got %v, want %v", negative, tt.wantNegative)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatTemplate(t *testing.T) {
+	tests := []struct {
+		number   string
+		localeID string
+		tmpl     string
+		want     string
+	}{
+		{"-1234.5", "en", "{sign}{symbol}{integer}{decimal}{fraction} ({code})", "-$1,234.50 (USD)"},
+		{"1234.5", "en", "{sign}{symbol}{integer}{decimal}{fraction} ({code})", "$1,234.50 (USD)"},
+		{"-1234.5", "de", "{integer}{decimal}{fraction} {code} [{sign}]", "1.234,50 USD [-]"},
+		{"-1234.5", "sv", "{sign}{integer}{decimal}{fraction}", "−1 234,50"},
+		{"1234.5", "ar", "{integer}{decimal}{fraction}", "١٬٢٣٤٫٥٠"},
+		{"1234.5", "en", "{code}{code}", "USDUSD"},
+		{"1234.5", "en", "no placeholders", "no placeholders"},
+		{"1234.5", "en", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tmpl, func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale(tt.localeID))
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			got, err := formatter.FormatTemplate(amount, tt.tmpl)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// The symbol is shown regardless of CurrencyDisplay,
+	// while the other settings apply.
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	formatter.CurrencyDisplay = currency.DisplayCode
+	formatter.AddPlusSign = true
+	formatter.ASCIIMinusSign = true
+	formatter.MinDigits = 0
+	amount, _ := currency.NewAmount("1234", "USD")
+	got, _ := formatter.FormatTemplate(amount, "{sign}{symbol}{integer}{decimal}{fraction}")
+	if got != "+$1,234" {
+		t.Errorf("got %q, want +$1,234", got)
+	}
+
+	for _, tmpl := range []string{"{name}", "{integer", "{}", "{ sign }", "{sign}{"} {
+		_, err := formatter.FormatTemplate(amount, tmpl)
+		if e, ok := err.(currency.InvalidTemplateError); ok {
+			if e.Template != tmpl {
+				t.Errorf("got %v, want %v", e.Template, tmpl)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidTemplateError", err)
+		}
+	}
+}
+
+func TestFormatter_FormatDelta(t *testing.T) {
+	tests := []struct {
+		number        string
+		localeID      string
+		noDeltaArrows bool
+		want          string
+	}{
+		{"10", "en-US", false, "▲+$10.00"},
+		{"-10", "en-US", false, "▼-$10.00"},
+		{"0", "en-US", false, "—"},
+		{"-0.00", "en-US", false, "—"},
+		{"10", "en-US", true, "+$10.00"},
+		{"-10", "en-US", true, "-$10.00"},
+		{"0", "en-US", true, "$0.00"},
+		{"10", "de-CH", false, "▲$+10.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.NoDeltaArrows = tt.noDeltaArrows
+			got, err := formatter.FormatDelta(amount)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			// Confirm that the formatter is unchanged.
+			if formatter.AddPlusSign {
+				t.Errorf("expected AddPlusSign to remain false")
+			}
+		})
+	}
+
+	formatter := currency.NewFormatter(currency.NewLocale("en-US"))
+	_, err := formatter.FormatDelta(currency.Amount{})
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "" {
+			t.Errorf("got %v, want empty currency code", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+}
+
+func TestFormatter_FormatScaledUnit(t *testing.T) {
+	tests := []struct {
+		number   string
+		localeID string
+		unit     currency.ScaledUnit
+		want     string
+	}{
+		{"0.0125", "en", currency.PerMille, "12.5‰"},
+		{"0.0125", "en", currency.BasisPoints, "125\u00a0bps"},
+		{"0.05", "en", currency.BasisPoints, "500\u00a0bps"},
+		{"-0.0125", "en", currency.BasisPoints, "-125\u00a0bps"},
+		{"1.5", "en", currency.BasisPoints, "15,000\u00a0bps"},
+		{"0.0125", "de", currency.PerMille, "12,5‰"},
+		{"-0.0125", "sv", currency.PerMille, "−12,5‰"},
+		{"0", "en", currency.PerMille, "0‰"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got, err := formatter.FormatScaledUnit(amount, tt.unit)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	amount, _ := currency.NewAmount("0.0125", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	_, err := formatter.FormatScaledUnit(amount, currency.ScaledUnit(7))
+	if e, ok := err.(currency.InvalidUnitError); ok {
+		if e.Unit != "7" {
+			t.Errorf("got %v, want 7", e.Unit)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidUnitError", err)
+	}
+}
+
+func TestFormatter_FormatUnitPrice(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		unit         string
+		want         string
+	}{
+		{"2.50", "USD", "en-US", "kg", "$2.50/kg"},
+		{"2.50", "EUR", "fr-FR", "kg", "2,50\u00a0€/kg"},
+		{"1234.5", "EUR", "fr-FR", "l", "1\u202f234,50\u00a0€/l"},
+		{"-2.50", "USD", "en-US", "lb", "-$2.50/lb"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got, err := formatter.FormatUnitPrice(amount, tt.unit)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	amount, _ := currency.NewAmount("2.50", "USD")
+	formatter := currency.NewFormatter(currency.NewLocale("en-US"))
+	for _, unit := range []string{"", " ", "\u00a0"} {
+		_, err := formatter.FormatUnitPrice(amount, unit)
+		if e, ok := err.(currency.InvalidUnitError); ok {
+			if e.Unit != unit {
+				t.Errorf("got %q, want %q", e.Unit, unit)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidUnitError", err)
+		}
+	}
+}
+
+func TestFormatter_Grouping(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		NoGrouping   bool
+		want         string
+	}{
+		{"123.99", "USD", "en", false, "$123.99"},
+		{"1234.99", "USD", "en", false, "$1,234.99"},
+		{"1234567.99", "USD", "en", false, "$1,234,567.99"},
+
+		{"123.99", "USD", "en", true, "$123.99"},
+		{"1234.99", "USD", "en", true, "$1234.99"},
+		{"1234567.99", "USD", "en", true, "$1234567.99"},
+
+		// The "es" locale has a different minGroupingSize.
+		{"123.99", "USD", "es", false, "123,99\u00a0US$"},
+		{"1234.99", "USD", "es", false, "1234,99\u00a0US$"},
+		{"12345.99", "USD", "es", false, "12.345,99\u00a0US$"},
+		{"1234567.99", "USD", "es", false, "1.234.567,99\u00a0US$"},
+
+		// The "hi" locale has a different secondaryGroupingSize.
+		{"123.99", "USD", "hi", false, "$123.99"},
+		{"1234.99", "USD", "hi", false, "$1,234.99"},
+		{"1234567.99", "USD", "hi", false, "$12,34,567.99"},
+		{"12345678.99", "USD", "hi", false, "$1,23,45,678.99"},
+
+		// The "bg" locale doesn't support grouping.
+		{"123.99", "EUR", "bg", false, "123,99\u00a0€"},
+		{"1234.99", "EUR", "bg", false, "1234,99\u00a0€"},
+		{"1234567.99", "EUR", "bg", false, "1234567,99\u00a0€"},
+
+		// Very large numbers.
+		{"1234567890123456789012345678901234567890", "USD", "en", false, "$1,234,567,890,123,456,789,012,345,678,901,234,567,890.00"},
+		{"12345678901234567890123456789012345678901234567890", "USD", "en", false, "$12,345,678,901,234,567,890,123,456,789,012,345,678,901,234,567,890.00"},
+		{"123456789012345678901234567890123456789012345678901", "USD", "en", false, "$123,456,789,012,345,678,901,234,567,890,123,456,789,012,345,678,901.00"},
+		{"1234567890123456789012345678901234567890", "USD", "hi", false, "$1,23,45,67,89,01,23,45,67,89,01,23,45,67,89,01,23,45,67,890.00"},
+		{"12345678901234567890123456789012345678901", "USD", "hi", false, "$12,34,56,78,90,12,34,56,78,90,12,34,56,78,90,12,34,56,78,901.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.NoGrouping = tt.NoGrouping
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FrenchGroupingSeparator(t *testing.T) {
+	// French uses a narrow no-break space (U+202F) for grouping,
+	// except for fr-CA, which uses a no-break space (U+00A0).
+	tests := []struct {
+		localeID string
+		want     []byte
+	}{
+		{"fr", []byte{0xe2, 0x80, 0xaf}},
+		{"fr-FR", []byte{0xe2, 0x80, 0xaf}},
+		{"fr-CH", []byte{0xe2, 0x80, 0xaf}},
+		{"fr-CA", []byte{0xc2, 0xa0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale(tt.localeID))
+			formatter.CurrencyDisplay = currency.DisplayNone
+			amount, _ := currency.NewAmount("1234", "EUR")
+			got := []byte(formatter.Format(amount))
+			want := append(append([]byte("1"), tt.want...), []byte("234")...)
+			if !bytes.HasPrefix(got, want) {
+				t.Errorf("got % x, want prefix % x", got, want)
+			}
+		})
+	}
+}
+
+func TestFormatter_PlusSign(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		AddPlusSign  bool
+		want         string
+	}{
+		{"123.99", "USD", "en", false, "$123.99"},
+		{"123.99", "USD", "en", true, "+$123.99"},
+
+		{"123.99", "USD", "de-CH", false, "$\u00a0123.99"},
+		{"123.99", "USD", "de-CH", true, "$+123.99"},
+
+		{"123.99", "USD", "fr-FR", false, "123,99\u00a0$US"},
+		{"123.99", "USD", "fr-FR", true, "+123,99\u00a0$US"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.AddPlusSign = tt.AddPlusSign
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_ASCIIMinusSign(t *testing.T) {
+	tests := []struct {
+		number         string
+		currencyCode   string
+		localeID       string
+		asciiMinusSign bool
+		addPlusSign    bool
+		want           string
+	}{
+		{"-1234.59", "SEK", "sv", false, false, "−1\u00a0234,59\u00a0kr"},
+		{"-1234.59", "SEK", "sv", true, false, "-1\u00a0234,59\u00a0kr"},
+		{"1234.59", "SEK", "sv", true, true, "+1\u00a0234,59\u00a0kr"},
+		{"-1234.59", "USD", "fa", false, false, "\u200e−\u200e$۱٬۲۳۴٫۵۹"},
+		{"-1234.59", "USD", "fa", true, false, "-\u200e$۱٬۲۳۴٫۵۹"},
+		{"1234.59", "USD", "fa", true, true, "+\u200e$۱٬۲۳۴٫۵۹"},
+		{"-1234.59", "USD", "en", true, false, "-$1,234.59"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.ASCIIMinusSign = tt.asciiMinusSign
+			formatter.AddPlusSign = tt.addPlusSign
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// Confirm that the output uses the ASCII hyphen-minus (0x2D).
+	locale := currency.NewLocale("sv")
+	formatter := currency.NewFormatter(locale)
+	formatter.ASCIIMinusSign = true
+	amount, _ := currency.NewAmount("-5", "SEK")
+	got := formatter.Format(amount)
+	if got[0] != 0x2D {
+		t.Errorf("got %x, want 2d", got[0])
+	}
+}
+
+func TestFormatter_NumberingSystem(t *testing.T) {
+	tests := []struct {
+		number          string
+		localeID        string
+		numberingSystem string
+		want            string
+	}{
+		{"12345678.90", "ar", "", "١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
+		{"12345678.90", "ar", "latn", "12٬345٬678٫90\u00a0US$"},
+		{"12345678.90", "en", "arab", "$١٢,٣٤٥,٦٧٨.٩٠"},
+		{"12345678.90", "en", "deva", "$१२,३४५,६७८.९०"},
+		// Unknown numbering systems are ignored.
+		{"12345678.90", "ar", "klingon", "١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.NumberingSystem = tt.numberingSystem
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			parsed, err := formatter.Parse(got, "USD")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if parsed.Number() != tt.number {
+				t.Errorf("got %v, want %v", parsed.Number(), tt.number)
+			}
+		})
+	}
+}
+
+func TestFormatter_Digits(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		minDigits    uint8
+		maxDigits    uint8
+		want         string
+	}{
+		{"59", "KRW", "en", currency.DefaultDigits, 6, "₩59"},
+		{"59", "USD", "en", currency.DefaultDigits, 6, "$59.00"},
+		{"59", "OMR", "en", currency.DefaultDigits, 6, "OMR\u00a059.000"},
+
+		{"59.6789", "KRW", "en", 0, currency.DefaultDigits, "₩60"},
+		{"59.6789", "USD", "en", 0, currency.DefaultDigits, "$59.68"},
+		{"59.6789", "OMR", "en", 0, currency.DefaultDigits, "OMR\u00a059.679"},
+
+		// minDigits:0 strips all trailing zeroes.
+		{"59", "USD", "en", 0, 6, "$59"},
+		{"59.5", "USD", "en", 0, 6, "$59.5"},
+		{"59.56", "USD", "en", 0, 6, "$59.56"},
+
+		// minDigits can't override maxDigits.
+		{"59.5", "USD", "en", 3, 2, "$59.50"},
+		{"59.567", "USD", "en", 3, 2, "$59.57"},
+
+		// maxDigits rounds the number.
+		{"59.5", "USD", "en", 2, 3, "$59.50"},
+		{"59.567", "USD", "en", 2, 3, "$59.567"},
+		{"59.5678", "USD", "en", 2, 3, "$59.568"},
+
+		// maxDigits below the currency's digits rounds the number,
+		// even when minDigits is the default.
+		{"1234.56", "USD", "en", currency.DefaultDigits, 0, "$1,235"},
+		{"1234.56", "USD", "en", currency.DefaultDigits, 1, "$1,234.6"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.MinDigits = tt.minDigits
+			formatter.MaxDigits = tt.maxDigits
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_HideZeroFraction(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		minDigits    uint8
+		want         string
+	}{
+		{"1234.00", "USD", "en", currency.DefaultDigits, "$1,234"},
+		{"1234.50", "USD", "en", currency.DefaultDigits, "$1,234.50"},
+		{"1234.001", "USD", "en", currency.DefaultDigits, "$1,234.001"},
+		{"1234", "USD", "en", 4, "$1,234"},
+		{"1234.5", "USD", "en", 4, "$1,234.5000"},
+		{"-1234.00", "EUR", "de", currency.DefaultDigits, "-1.234\u00a0€"},
+		{"1234.0000001", "USD", "en", currency.DefaultDigits, "$1,234"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.MinDigits = tt.minDigits
+			formatter.HideZeroFraction = true
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_SuperscriptFraction(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"12.99", "USD", "en", "$12⁹⁹"},
+		{"1234.05", "USD", "en", "$1,234⁰⁵"},
+		{"-12.99", "USD", "en", "-$12⁹⁹"},
+		{"12.99", "EUR", "de", "12⁹⁹\u00a0€"},
+		// No fraction digits.
+		{"12", "JPY", "ja", "￥12"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.SuperscriptFraction = true
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_RegisteredCurrency(t *testing.T) {
+	currency.RestoreDataOnCleanup(t)
+	err := currency.RegisterCurrencyData(currency.CurrencyData{
+		CurrencyCode: "BTC",
+		Digits:       8,
+		Symbols:      map[string]string{"en": "₿"},
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	numericCode, _ := currency.GetNumericCode("BTC")
+	if numericCode != "000" {
+		t.Errorf("got %v, want 000", numericCode)
+	}
+	amount, _ := currency.NewAmount("0.12345678", "BTC")
+	digits, _ := currency.GetDigits("BTC")
+	if got := amount.RoundTo(digits, currency.RoundHalfUp).Number(); got != "0.12345678" {
+		t.Errorf("got %v, want 0.12345678", got)
+	}
+
+	tests := []struct {
+		number   string
+		localeID string
+		want     string
+	}{
+		{"0.12345678", "en", "₿0.12345678"},
+		{"1234.5", "en", "₿1,234.50000000"},
+		{"0.123456789", "en", "₿0.12345679"},
+		{"0.12345678", "de", "0,12345678 ₿"},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "BTC")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_RegisteredLocaleFormat(t *testing.T) {
+	currency.RestoreDataOnCleanup(t)
+	spec := currency.FormatSpec{
+		Pattern:               "0.00 ¤;(0.00 ¤)",
+		MinGroupingDigits:     1,
+		PrimaryGroupingSize:   3,
+		SecondaryGroupingSize: 2,
+		DecimalSeparator:      ":",
+		GroupingSeparator:     "'",
+		PlusSign:              "+",
+		MinusSign:             "-",
+	}
+	invalidTests := []struct {
+		localeID string
+		modify   func(spec *currency.FormatSpec)
+	}{
+		{"qaa", func(spec *currency.FormatSpec) { spec.Pattern = "" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.Pattern = "¤0" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.Pattern = "¤0.00;(¤0)" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.DecimalSeparator = "" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.DecimalSeparator, spec.GroupingSeparator = ".", "." }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.GroupingSeparator = "" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.GroupingSeparator, spec.PrimaryGroupingSize = "", 0 }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.PlusSign = "" }},
+		{"qaa", func(spec *currency.FormatSpec) { spec.MinusSign = "" }},
+	}
+	for _, tt := range invalidTests {
+		t.Run("", func(t *testing.T) {
+			invalidSpec := spec
+			tt.modify(&invalidSpec)
+			err := currency.RegisterLocaleFormat(tt.localeID, invalidSpec)
+			if e, ok := err.(currency.InvalidFormatSpecError); ok {
+				if e.LocaleID != tt.localeID {
+					t.Errorf("got %v, want %v", e.LocaleID, tt.localeID)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidFormatSpecError", err)
+			}
+		})
+	}
+	for _, localeID := range []string{"", "!", "q"} {
+		err := currency.RegisterLocaleFormat(localeID, spec)
+		if _, ok := err.(currency.InvalidLocaleError); !ok {
+			t.Errorf("got %T, want currency.InvalidLocaleError", err)
+		}
+	}
+
+	// "qaa" is reserved for local use, and has no CLDR data.
+	err := currency.RegisterLocaleFormat("qaa", spec)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		number   string
+		localeID string
+		want     string
+	}{
+		{"1234567.89", "qaa", "12'34'567:89 $"},
+		{"-1234.5", "qaa", "(1'234:50 $)"},
+		// Child locales fall back to the registered format.
+		{"1234567.89", "qaa-ZZ", "12'34'567:89 $"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			if formatter.ResolvedLocale().String() != "qaa" {
+				t.Errorf("got %v, want qaa", formatter.ResolvedLocale())
+			}
+			parsed, err := formatter.Parse(got, "USD")
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !parsed.Equal(amount) {
+				t.Errorf("got %v, want %v", parsed, amount)
+			}
+		})
+	}
+	_, err = currency.NewFormatterStrict(currency.NewLocale("qaa"))
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// A zero secondary grouping size is the same as the primary one.
+	err = currency.RegisterLocaleFormat("qab", currency.FormatSpec{
+		Pattern:             "¤0.00",
+		MinGroupingDigits:   1,
+		PrimaryGroupingSize: 3,
+		DecimalSeparator:    ".",
+		GroupingSeparator:   ",",
+		PlusSign:            "+",
+		MinusSign:           "-",
+	})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	formatter := currency.NewFormatter(currency.NewLocale("qab"))
+	for _, number := range []string{"1234567.89", "-1234.5", "12.5"} {
+		amount, _ := currency.NewAmount(number, "USD")
+		formatted := formatter.Format(amount)
+		parsed, err := formatter.Parse(formatted, "USD")
+		if err != nil {
+			t.Errorf("unexpected error for %q: %v", formatted, err)
+		}
+		if !parsed.Equal(amount) {
+			t.Errorf("got %v, want %v", parsed, amount)
+		}
+	}
+	amount, _ := currency.NewAmount("-1234567.89", "USD")
+	if got := formatter.Format(amount); got != "-$1,234,567.89" {
+		t.Errorf(`got %q, want "-$1,234,567.89"`, got)
+	}
+}
+
+func TestFormatter_MaxSignificantDigits(t *testing.T) {
+	tests := []struct {
+		number               string
+		minDigits            uint8
+		maxSignificantDigits uint8
+		want                 string
+	}{
+		{"12345.678", currency.DefaultDigits, 0, "$12,345.678"},
+		{"12345.678", currency.DefaultDigits, 4, "$12,350.00"},
+		{"12345.678", 0, 4, "$12,350"},
+		{"12345.678", currency.DefaultDigits, 6, "$12,345.70"},
+		{"999.99", currency.DefaultDigits, 4, "$1,000.00"},
+		{"12", currency.DefaultDigits, 4, "$12.00"},
+		{"-12345.678", currency.DefaultDigits, 2, "-$12,000.00"},
+		// Numbers smaller than 1.
+		{"1.23456", currency.DefaultDigits, 4, "$1.235"},
+		{"0.123456", currency.DefaultDigits, 2, "$0.12"},
+		{"0.00123456", currency.DefaultDigits, 4, "$0.001235"},
+		// MaxDigits is applied after MaxSignificantDigits.
+		{"0.000012345", currency.DefaultDigits, 4, "$0.000012"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			locale := currency.NewLocale("en")
+			formatter := currency.NewFormatter(locale)
+			formatter.MinDigits = tt.minDigits
+			formatter.MaxSignificantDigits = tt.maxSignificantDigits
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_RoundingMode(t *testing.T) {
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		roundingMode currency.RoundingMode
+		want         string
+	}{
+		{"1234.453", "USD", "en", currency.RoundHalfUp, "$1,234.45"},
+		{"1234.455", "USD", "en", currency.RoundHalfUp, "$1,234.46"},
+		{"1234.456", "USD", "en", currency.RoundHalfUp, "$1,234.46"},
+
+		{"1234.453", "USD", "en", currency.RoundHalfDown, "$1,234.45"},
+		{"1234.455", "USD", "en", currency.RoundHalfDown, "$1,234.45"},
+		{"1234.457", "USD", "en", currency.RoundHalfDown, "$1,234.46"},
+
+		{"1234.453", "USD", "en", currency.RoundUp, "$1,234.46"},
+		{"1234.455", "USD", "en", currency.RoundUp, "$1,234.46"},
+		{"1234.457", "USD", "en", currency.RoundUp, "$1,234.46"},
+
+		{"1234.453", "USD", "en", currency.RoundDown, "$1,234.45"},
+		{"1234.455", "USD", "en", currency.RoundDown, "$1,234.45"},
+		{"1234.457", "USD", "en", currency.RoundDown, "$1,234.45"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.MaxDigits = currency.DefaultDigits
+			formatter.RoundingMode = tt.roundingMode
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatter_FormatWithRounding(t *testing.T) {
+	tests := []struct {
+		number               string
+		maxDigits            uint8
+		maxSignificantDigits uint8
+		want                 string
+		wantRounded          bool
+	}{
+		{"1.005", 2, 0, "$1.01", true},
+		{"1.00", 2, 0, "$1.00", false},
+		{"1", 2, 0, "$1.00", false},
+		{"1.5000", 2, 0, "$1.50", false},
+		{"1.2345", 6, 0, "$1.2345", false},
+		{"1.2345", 3, 0, "$1.235", true},
+		{"-1.005", 2, 0, "-$1.01", true},
+		{"12345.67", 6, 4, "$12,350.00", true},
+		{"12340.00", 6, 4, "$12,340.00", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale("en"))
+			formatter.MaxDigits = tt.maxDigits
+			formatter.MaxSignificantDigits = tt.maxSignificantDigits
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			got, rounded := formatter.FormatWithRounding(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if rounded != tt.wantRounded {
+				t.Errorf("got %v, want %v", rounded, tt.wantRounded)
+			}
+		})
+	}
+}
+
+func TestFormatter_CurrencyDisplay(t *testing.T) {
+	tests := []struct {
+		number          string
+		currencyCode    string
+		localeID        string
+		currencyDisplay currency.Display
+		want            string
+	}{
+		{"1234.59", "USD", "en", currency.DisplaySymbol, "$1,234.59"},
+		{"1234.59", "USD", "en", currency.DisplayCode, "USD\u00a01,234.59"},
+		{"1234.59", "USD", "en", currency.DisplayNone, "1,234.59"},
+
+		{"1234.59", "USD", "de-AT", currency.DisplaySymbol, "$\u00a01.234,59"},
+		{"1234.59", "USD", "de-AT", currency.DisplayCode, "USD\u00a01.234,59"},
+		{"1234.59", "USD", "de-AT", currency.DisplayNone, "1.234,59"},
 
-		// Arabic digits.
-		{"12345678.90", "USD", "ar", "١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$"},
-		// Arabic extended (Persian) digits.
-		{"12345678.90", "USD", "fa", "\u200e$۱۲٬۳۴۵٬۶۷۸٫۹۰"},
-		// Bengali digits.
-		{"12345678.90", "USD", "bn", "১,২৩,৪৫,৬৭৮.৯০\u00a0US$"},
-		// Devanagari digits.
-		{"12345678.90", "USD", "ne", "US$\u00a0१,२३,४५,६७८.९०"},
-		// Myanmar (Burmese) digits.
-		{"12345678.90", "USD", "my", "၁၂,၃၄၅,၆၇၈.၉၀\u00a0US$"},
+		{"1234.59", "USD", "sr-Latn", currency.DisplaySymbol, "1.234,59\u00a0US$"},
+		{"1234.59", "USD", "sr-Latn", currency.DisplayCode, "1.234,59\u00a0USD"},
+		{"1234.59", "USD", "sr-Latn", currency.DisplayNone, "1.234,59"},
 	}
 
 	for _, tt := range tests {
@@ -60,6 +1224,7 @@ func TestFormatter_Format(t *testing.T) {
 			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
+			formatter.CurrencyDisplay = tt.currencyDisplay
 			got := formatter.Format(amount)
 			if got != tt.want {
 				t.Errorf("got %v, want %v", got, tt.want)
@@ -68,38 +1233,18 @@ func TestFormatter_Format(t *testing.T) {
 	}
 }
 
-func TestFormatter_Grouping(t *testing.T) {
+func TestFormatter_PreserveSymbolSpace(t *testing.T) {
 	tests := []struct {
 		number       string
 		currencyCode string
 		localeID     string
-		NoGrouping   bool
 		want         string
 	}{
-		{"123.99", "USD", "en", false, "$123.99"},
-		{"1234.99", "USD", "en", false, "$1,234.99"},
-		{"1234567.99", "USD", "en", false, "$1,234,567.99"},
-
-		{"123.99", "USD", "en", true, "$123.99"},
-		{"1234.99", "USD", "en", true, "$1234.99"},
-		{"1234567.99", "USD", "en", true, "$1234567.99"},
-
-		// The "es" locale has a different minGroupingSize.
-		{"123.99", "USD", "es", false, "123,99\u00a0US$"},
-		{"1234.99", "USD", "es", false, "1234,99\u00a0US$"},
-		{"12345.99", "USD", "es", false, "12.345,99\u00a0US$"},
-		{"1234567.99", "USD", "es", false, "1.234.567,99\u00a0US$"},
-
-		// The "hi" locale has a different secondaryGroupingSize.
-		{"123.99", "USD", "hi", false, "$123.99"},
-		{"1234.99", "USD", "hi", false, "$1,234.99"},
-		{"1234567.99", "USD", "hi", false, "$12,34,567.99"},
-		{"12345678.99", "USD", "hi", false, "$1,23,45,678.99"},
-
-		// The "bg" locale doesn't support grouping.
-		{"123.99", "EUR", "bg", false, "123,99\u00a0€"},
-		{"1234.99", "EUR", "bg", false, "1234,99\u00a0€"},
-		{"1234567.99", "EUR", "bg", false, "1234567,99\u00a0€"},
+		{"1234.59", "USD", "en", " 1,234.59"},
+		{"-1234.59", "USD", "en", "- 1,234.59"},
+		{"1234.59", "CHF", "en", "    1,234.59"},
+		{"1234.59", "EUR", "de", "1.234,59\u00a0 "},
+		{"1234.59", "USD", "sr", "1.234,59\u00a0   "},
 	}
 
 	for _, tt := range tests {
@@ -107,31 +1252,38 @@ func TestFormatter_Grouping(t *testing.T) {
 			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
-			formatter.NoGrouping = tt.NoGrouping
+			formatter.CurrencyDisplay = currency.DisplayNone
+			formatter.PreserveSymbolSpace = true
 			got := formatter.Format(amount)
 			if got != tt.want {
-				t.Errorf("got %v, want %v", got, tt.want)
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+
+			// Confirm that the width matches the amount with a symbol.
+			formatter.CurrencyDisplay = currency.DisplaySymbol
+			withSymbol := formatter.Format(amount)
+			if utf8.RuneCountInString(got) != utf8.RuneCountInString(withSymbol) {
+				t.Errorf("got width %v, want %v (%q)", utf8.RuneCountInString(got), utf8.RuneCountInString(withSymbol), withSymbol)
 			}
 		})
 	}
 }
 
-func TestFormatter_PlusSign(t *testing.T) {
+func TestFormatter_BidiMode(t *testing.T) {
 	tests := []struct {
 		number       string
 		currencyCode string
 		localeID     string
-		AddPlusSign  bool
+		bidiMode     currency.BidiMode
 		want         string
 	}{
-		{"123.99", "USD", "en", false, "$123.99"},
-		{"123.99", "USD", "en", true, "+$123.99"},
-
-		{"123.99", "USD", "de-CH", false, "$\u00a0123.99"},
-		{"123.99", "USD", "de-CH", true, "$+123.99"},
-
-		{"123.99", "USD", "fr-FR", false, "123,99\u00a0$US"},
-		{"123.99", "USD", "fr-FR", true, "+123,99\u00a0$US"},
+		{"1234.59", "USD", "ar", currency.BidiNone, "١٬٢٣٤٫٥٩\u00a0US$"},
+		{"1234.59", "USD", "ar", currency.BidiIsolate, "\u2068١٬٢٣٤٫٥٩\u2069\u00a0US$"},
+		{"-1234.59", "USD", "ar", currency.BidiIsolate, "\u061c-\u2068١٬٢٣٤٫٥٩\u2069\u00a0US$"},
+		{"1234.59", "USD", "en", currency.BidiIsolate, "$\u20681,234.59\u2069"},
+		{"1234.59", "USD", "ar", currency.BidiEmbedding, "\u202a١٬٢٣٤٫٥٩\u202c\u00a0US$"},
+		{"-1234.59", "USD", "ar", currency.BidiEmbedding, "\u061c-\u202a١٬٢٣٤٫٥٩\u202c\u00a0US$"},
+		{"1234.59", "USD", "en", currency.BidiEmbedding, "$\u202a1,234.59\u202c"},
 	}
 
 	for _, tt := range tests {
@@ -139,85 +1291,78 @@ func TestFormatter_PlusSign(t *testing.T) {
 			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
-			formatter.AddPlusSign = tt.AddPlusSign
+			formatter.BidiMode = tt.bidiMode
 			got := formatter.Format(amount)
 			if got != tt.want {
-				t.Errorf("got %v, want %v", got, tt.want)
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+			parsed, err := formatter.Parse(got, tt.currencyCode)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if parsed.Number() != tt.number {
+				t.Errorf("got %v, want %v", parsed.Number(), tt.number)
 			}
 		})
 	}
 }
 
-func TestFormatter_Digits(t *testing.T) {
+func TestFormatter_FormatCurrency(t *testing.T) {
 	tests := []struct {
-		number       string
-		currencyCode string
-		localeID     string
-		minDigits    uint8
-		maxDigits    uint8
-		want         string
+		currencyCode    string
+		localeID        string
+		currencyDisplay currency.Display
+		want            string
 	}{
-		{"59", "KRW", "en", currency.DefaultDigits, 6, "₩59"},
-		{"59", "USD", "en", currency.DefaultDigits, 6, "$59.00"},
-		{"59", "OMR", "en", currency.DefaultDigits, 6, "OMR\u00a059.000"},
-
-		{"59.6789", "KRW", "en", 0, currency.DefaultDigits, "₩60"},
-		{"59.6789", "USD", "en", 0, currency.DefaultDigits, "$59.68"},
-		{"59.6789", "OMR", "en", 0, currency.DefaultDigits, "OMR\u00a059.679"},
-
-		// minDigits:0 strips all trailing zeroes.
-		{"59", "USD", "en", 0, 6, "$59"},
-		{"59.5", "USD", "en", 0, 6, "$59.5"},
-		{"59.56", "USD", "en", 0, 6, "$59.56"},
+		{"USD", "en-US", currency.DisplaySymbol, "$"},
+		{"USD", "en-US", currency.DisplayCode, "USD"},
+		{"USD", "en-US", currency.DisplayNone, ""},
 
-		// minDigits can't override maxDigits.
-		{"59.5", "USD", "en", 3, 2, "$59.50"},
-		{"59.567", "USD", "en", 3, 2, "$59.57"},
+		{"CAD", "en-US", currency.DisplaySymbol, "CA$"},
+		{"CAD", "en-US", currency.DisplayCode, "CAD"},
+		{"CAD", "en-US", currency.DisplayNone, ""},
 
-		// maxDigits rounds the number.
-		{"59.5", "USD", "en", 2, 3, "$59.50"},
-		{"59.567", "USD", "en", 2, 3, "$59.567"},
-		{"59.5678", "USD", "en", 2, 3, "$59.568"},
+		{"USD", "fr", currency.DisplaySymbol, "$US"},
+		{"USD", "fr", currency.DisplayCode, "USD"},
 	}
 
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
-			formatter.MinDigits = tt.minDigits
-			formatter.MaxDigits = tt.maxDigits
-			got := formatter.Format(amount)
+			formatter.CurrencyDisplay = tt.currencyDisplay
+			got := formatter.FormatCurrency(tt.currencyCode)
 			if got != tt.want {
 				t.Errorf("got %v, want %v", got, tt.want)
 			}
 		})
 	}
+
+	// Custom symbols are respected.
+	locale := currency.NewLocale("en-US")
+	formatter := currency.NewFormatter(locale)
+	formatter.SymbolMap["CAD"] = "C$"
+	got := formatter.FormatCurrency("CAD")
+	if got != "C$" {
+		t.Errorf("got %v, want C$", got)
+	}
 }
 
-func TestFormatter_RoundingMode(t *testing.T) {
+func TestFormatter_AmbiguousCurrencyDisplay(t *testing.T) {
 	tests := []struct {
 		number       string
 		currencyCode string
 		localeID     string
-		roundingMode currency.RoundingMode
 		want         string
 	}{
-		{"1234.453", "USD", "en", currency.RoundHalfUp, "$1,234.45"},
-		{"1234.455", "USD", "en", currency.RoundHalfUp, "$1,234.46"},
-		{"1234.456", "USD", "en", currency.RoundHalfUp, "$1,234.46"},
+		{"10", "USD", "en", "USD\u00a010.00"},
+		{"10", "CAD", "en", "CAD\u00a010.00"},
+		{"10", "GBP", "en", "GBP\u00a010.00"},
+		{"10", "EUR", "en", "€10.00"},
+		{"10", "RSD", "en", "RSD\u00a010"},
 
-		{"1234.453", "USD", "en", currency.RoundHalfDown, "$1,234.45"},
-		{"1234.455", "USD", "en", currency.RoundHalfDown, "$1,234.45"},
-		{"1234.457", "USD", "en", currency.RoundHalfDown, "$1,234.46"},
-
-		{"1234.453", "USD", "en", currency.RoundUp, "$1,234.46"},
-		{"1234.455", "USD", "en", currency.RoundUp, "$1,234.46"},
-		{"1234.457", "USD", "en", currency.RoundUp, "$1,234.46"},
-
-		{"1234.453", "USD", "en", currency.RoundDown, "$1,234.45"},
-		{"1234.455", "USD", "en", currency.RoundDown, "$1,234.45"},
-		{"1234.457", "USD", "en", currency.RoundDown, "$1,234.45"},
+		{"10", "USD", "de", "10,00\u00a0USD"},
+		{"10", "EUR", "de", "10,00\u00a0€"},
 	}
 
 	for _, tt := range tests {
@@ -225,35 +1370,135 @@ func TestFormatter_RoundingMode(t *testing.T) {
 			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
-			formatter.MaxDigits = currency.DefaultDigits
-			formatter.RoundingMode = tt.roundingMode
+			formatter.AmbiguousCurrencyDisplay = currency.DisplayCode
 			got := formatter.Format(amount)
 			if got != tt.want {
 				t.Errorf("got %v, want %v", got, tt.want)
 			}
 		})
 	}
+
+	// Ambiguous currencies can be customized.
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	formatter.AmbiguousCurrencyDisplay = currency.DisplayCode
+	formatter.AmbiguousCurrencies = []string{"EUR"}
+	amount, _ := currency.NewAmount("10", "USD")
+	got := formatter.Format(amount)
+	if got != "$10.00" {
+		t.Errorf("got %v, want $10.00", got)
+	}
+	amount, _ = currency.NewAmount("10", "EUR")
+	got = formatter.Format(amount)
+	if got != "EUR\u00a010.00" {
+		t.Errorf("got %v, want EUR\u00a010.00", got)
+	}
+
+	// Only applies when showing symbols.
+	formatter.CurrencyDisplay = currency.DisplayNone
+	got = formatter.Format(amount)
+	if got != "10.00" {
+		t.Errorf("got %v, want 10.00", got)
+	}
 }
 
-func TestFormatter_CurrencyDisplay(t *testing.T) {
+func TestFormatter_SymbolMap(t *testing.T) {
+	locale := currency.NewLocale("en")
+	formatter := currency.NewFormatter(locale)
+	formatter.SymbolMap["USD"] = "US$"
+	formatter.SymbolMap["EUR"] = "EU"
+
+	amount, _ := currency.NewAmount("6.99", "USD")
+	got := formatter.Format(amount)
+	if got != "US$6.99" {
+		t.Errorf("got %v, want US$6.99", got)
+	}
+
+	amount, _ = currency.NewAmount("6.99", "EUR")
+	got = formatter.Format(amount)
+	if got != "EU\u00a06.99" {
+		t.Errorf("got %v, want EU\u00a06.99", got)
+	}
+
+	// Custom symbols are not used when displaying the currency code.
+	formatter.CurrencyDisplay = currency.DisplayCode
+	formatter.LocaleSymbolMap["en"] = map[string]string{"USD": "U$"}
+	amount, _ = currency.NewAmount("6.99", "usd")
+	got = formatter.Format(amount)
+	if got != "USD\u00a06.99" {
+		t.Errorf("got %v, want USD\u00a06.99", got)
+	}
+	got = formatter.FormatCurrency("usd")
+	if got != "USD" {
+		t.Errorf("got %v, want USD", got)
+	}
+}
+
+func TestFormatter_NegativeSymbolMap(t *testing.T) {
 	tests := []struct {
 		number          string
-		currencyCode    string
-		localeID        string
 		currencyDisplay currency.Display
 		want            string
 	}{
-		{"1234.59", "USD", "en", currency.DisplaySymbol, "$1,234.59"},
-		{"1234.59", "USD", "en", currency.DisplayCode, "USD\u00a01,234.59"},
-		{"1234.59", "USD", "en", currency.DisplayNone, "1,234.59"},
+		{"-6.99", currency.DisplaySymbol, "-CR\u00a06.99"},
+		{"6.99", currency.DisplaySymbol, "US$6.99"},
+		{"0", currency.DisplaySymbol, "US$0.00"},
+		{"-0", currency.DisplaySymbol, "US$0.00"},
+		{"-6.99", currency.DisplayCode, "-USD\u00a06.99"},
+		{"-6.99", currency.DisplayNone, "-6.99"},
+	}
 
-		{"1234.59", "USD", "de-AT", currency.DisplaySymbol, "$\u00a01.234,59"},
-		{"1234.59", "USD", "de-AT", currency.DisplayCode, "USD\u00a01.234,59"},
-		{"1234.59", "USD", "de-AT", currency.DisplayNone, "1.234,59"},
+	for _, tt := range tests {
+		t.Run(tt.number, func(t *testing.T) {
+			formatter := currency.NewFormatter(currency.NewLocale("en"))
+			formatter.SymbolMap["USD"] = "US$"
+			formatter.NegativeSymbolMap["USD"] = "CR"
+			formatter.CurrencyDisplay = tt.currencyDisplay
+			amount, _ := currency.NewAmount(tt.number, "USD")
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
 
-		{"1234.59", "USD", "sr-Latn", currency.DisplaySymbol, "1.234,59\u00a0US$"},
-		{"1234.59", "USD", "sr-Latn", currency.DisplayCode, "1.234,59\u00a0USD"},
-		{"1234.59", "USD", "sr-Latn", currency.DisplayNone, "1.234,59"},
+	// Currencies not in the map keep their usual symbol.
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	formatter.NegativeSymbolMap["USD"] = "CR"
+	amount, _ := currency.NewAmount("-6.99", "EUR")
+	got := formatter.Format(amount)
+	if got != "-€6.99" {
+		t.Errorf("got %q, want -€6.99", got)
+	}
+	// FormatCurrency has no amount, and uses the usual symbol.
+	got = formatter.FormatCurrency("USD")
+	if got != "$" {
+		t.Errorf("got %q, want $", got)
+	}
+}
+
+func TestFormatter_LocaleSymbolMap(t *testing.T) {
+	localeSymbolMap := map[string]map[string]string{
+		"de": {"EUR": "EUR€"},
+		"fr": {"EUR": "€uro", "USD": "$"},
+	}
+	tests := []struct {
+		number       string
+		currencyCode string
+		localeID     string
+		want         string
+	}{
+		{"6.99", "EUR", "de", "6,99\u00a0EUR€"},
+		{"6.99", "EUR", "de-AT", "EUR€\u00a06,99"},
+		{"6.99", "EUR", "fr", "6,99\u00a0€uro"},
+		{"6.99", "EUR", "fr-CA", "6,99\u00a0€uro"},
+		{"6.99", "USD", "fr", "6,99\u00a0$"},
+		// No custom symbol for the locale, SymbolMap is used.
+		{"6.99", "EUR", "en", "EU\u00a06.99"},
+		// No custom symbol for the currency, SymbolMap is used.
+		{"6.99", "USD", "de", "6,99\u00a0USD$"},
+		// No custom symbol at all.
+		{"6.99", "GBP", "de", "6,99\u00a0£"},
 	}
 
 	for _, tt := range tests {
@@ -261,35 +1506,92 @@ func TestFormatter_CurrencyDisplay(t *testing.T) {
 			amount, _ := currency.NewAmount(tt.number, tt.currencyCode)
 			locale := currency.NewLocale(tt.localeID)
 			formatter := currency.NewFormatter(locale)
-			formatter.CurrencyDisplay = tt.currencyDisplay
+			formatter.LocaleSymbolMap = localeSymbolMap
+			formatter.SymbolMap["EUR"] = "EU"
+			formatter.SymbolMap["USD"] = "USD$"
 			got := formatter.Format(amount)
 			if got != tt.want {
 				t.Errorf("got %v, want %v", got, tt.want)
 			}
 		})
 	}
-}
 
-func TestFormatter_SymbolMap(t *testing.T) {
-	locale := currency.NewLocale("en")
-	formatter := currency.NewFormatter(locale)
-	formatter.SymbolMap["USD"] = "US$"
-	formatter.SymbolMap["EUR"] = "EU"
-
-	amount, _ := currency.NewAmount("6.99", "USD")
-	got := formatter.Format(amount)
-	if got != "US$6.99" {
-		t.Errorf("got %v, want US$6.99", got)
+	// Symbols defined for "en" must not leak into non-English locales.
+	enSymbolMap := map[string]map[string]string{
+		"en": {"EUR": "X"},
 	}
-
-	amount, _ = currency.NewAmount("6.99", "EUR")
-	got = formatter.Format(amount)
-	if got != "EU\u00a06.99" {
-		t.Errorf("got %v, want EU\u00a06.99", got)
+	enTests := []struct {
+		localeID string
+		want     string
+	}{
+		{"fr", "1,00\u00a0Y"},
+		{"en", "X\u00a01.00"},
+		{"en-GB", "X\u00a01.00"},
+	}
+	for _, tt := range enTests {
+		t.Run(tt.localeID, func(t *testing.T) {
+			amount, _ := currency.NewAmount("1", "EUR")
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			formatter.LocaleSymbolMap = enSymbolMap
+			formatter.SymbolMap["EUR"] = "Y"
+			got := formatter.Format(amount)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
 	}
 }
 
 func TestFormatter_Parse(t *testing.T) {
+	invalidTests := []struct {
+		s        string
+		localeID string
+	}{
+		// Multiple decimal separators.
+		{"1.234.56", "en"},
+		{"1,234,56", "de"},
+		// Grouping separator after the decimal separator.
+		{"1.234,56", "en"},
+		{"1,234.56", "de"},
+		// Grouping separator in impossible positions.
+		{"1,23", "en"},
+		{"1,2345", "en"},
+		{"1,23,456", "en"},
+		{"12345,678", "en"},
+		{",123", "en"},
+		{"1,,234", "en"},
+		// Parentheses with an explicit sign.
+		{"(-$1,234.56)", "en"},
+		{"(+$1,234.56)", "en"},
+		{"1 23,45", "fr"},
+		{"1,234,567.89", "hi"},
+		// Digits from more than one numbering system.
+		{"١٢34", "ar"},
+		{"12٫٣٤", "ar"},
+		{"۱۲٣٤", "fa"},
+		{"US$\u00a0१,२३4", "ne"},
+		// Underscores are not grouping separators.
+		{"1_234", "en"},
+		{"$1_234.50", "en"},
+		{"12_345_678", "en"},
+		{"1_234,56", "fr"},
+	}
+	for _, tt := range invalidTests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			formatter := currency.NewFormatter(locale)
+			_, err := formatter.Parse(tt.s, "USD")
+			if e, ok := err.(currency.InvalidNumberError); ok {
+				if e.Number != tt.s {
+					t.Errorf("got %v, want %v", e.Number, tt.s)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidNumberError", err)
+			}
+		})
+	}
+
 	tests := []struct {
 		s            string
 		currencyCode string
@@ -307,12 +1609,27 @@ func TestFormatter_Parse(t *testing.T) {
 		{"-USD\u00a01,234.59", "USD", "en", "-1234.59"},
 		{"-1,234.59", "USD", "en", "-1234.59"},
 		{"-1234.59", "USD", "en", "-1234.59"},
+		{"($1,234.59)", "USD", "en", "-1234.59"},
+		{"(USD\u00a01,234.59)", "USD", "en", "-1234.59"},
+		{"(1234.59)", "USD", "en", "-1234.59"},
+		{"(1.234,59\u00a0€)", "EUR", "de", "-1234.59"},
 
 		{"€\u00a01.234,00", "EUR", "de-AT", "1234.00"},
 		{"EUR\u00a01.234,00", "EUR", "de-AT", "1234.00"},
 		{"1.234,00", "EUR", "de-AT", "1234.00"},
 		{"1234,00", "EUR", "de-AT", "1234.00"},
 
+		// Separators are interpreted according to the locale.
+		{"1,234", "USD", "en", "1234"},
+		{"1,234", "USD", "de", "1.234"},
+		{"1.234", "USD", "en", "1.234"},
+		{"1.234", "USD", "de", "1234"},
+		{"1,234,567.89", "USD", "en", "1234567.89"},
+		{"1.234.567,89", "USD", "de", "1234567.89"},
+		{"1\u202f234\u202f567,89\u00a0$US", "USD", "fr", "1234567.89"},
+		{"1 234 567,89 $US", "USD", "fr", "1234567.89"},
+		{"12,34,567.89", "INR", "hi", "1234567.89"},
+
 		// Arabic digits.
 		{"١٢٬٣٤٥٬٦٧٨٫٩٠\u00a0US$", "USD", "ar", "12345678.90"},
 		// Arabic extended (Persian) digits.
@@ -325,6 +1642,21 @@ func TestFormatter_Parse(t *testing.T) {
 		{"၁၂,၃၄၅,၆၇၈.၉၀\u00a0US$", "USD", "my", "12345678.90"},
 	}
 
+	// A single parenthesis is not treated as a negative indicator.
+	formatter := currency.NewFormatter(currency.NewLocale("en"))
+	for _, s := range []string{"($1,234.56", "$1,234.56)"} {
+		_, err := formatter.Parse(s, "USD")
+		if _, ok := err.(currency.InvalidNumberError); !ok {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+	// Both forms produce the same amount.
+	a, _ := formatter.Parse("-$1,234.56", "USD")
+	b, _ := formatter.Parse("($1,234.56)", "USD")
+	if !a.Equal(b) {
+		t.Errorf("got %v, want %v", b, a)
+	}
+
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
 			locale := currency.NewLocale(tt.localeID)
@@ -342,3 +1674,79 @@ func TestFormatter_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		s        string
+		localeID string
+		want     string
+	}{
+		{"20", "en", "20"},
+		{"20%", "en", "20"},
+		{"20 %", "en", "20"},
+		{" 8.25% ", "en", "8.25"},
+		{"-5%", "en", "-5"},
+		{"20%", "de", "20"},
+		{"20\u00a0%", "de", "20"},
+		{"20,5", "de", "20.5"},
+		{"20,5\u00a0%", "de", "20.5"},
+		{"−7,5\u00a0%", "sv", "-7.5"},
+		{"١٥٫٥٪", "ar", "15.5"},
+		{"0.0000001%", "en", "0.0000001"},
+		{"1000%", "en", "1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			got, err := currency.ParsePercent(tt.s, locale)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	invalidTests := []struct {
+		s        string
+		localeID string
+	}{
+		{"", "en"},
+		{"%", "en"},
+		{"%20", "en"},
+		{"20%%", "en"},
+		{"20 0%", "en"},
+		{"20,5%", "en"},
+		{"20.5%", "de"},
+		{"1.000,5%", "de"},
+		{"NaN", "en"},
+		{"Infinity%", "en"},
+		{"١5٫٥٪", "ar"},
+		{"1e3", "en"},
+		{"1E3%", "en"},
+		{"2,5e-1 %", "de"},
+	}
+	for _, tt := range invalidTests {
+		t.Run("", func(t *testing.T) {
+			locale := currency.NewLocale(tt.localeID)
+			_, err := currency.ParsePercent(tt.s, locale)
+			if e, ok := err.(currency.InvalidNumberError); ok {
+				if e.Number != tt.s {
+					t.Errorf("got %v, want %v", e.Number, tt.s)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidNumberError", err)
+			}
+		})
+	}
+
+	// Parsed percentages can be used directly.
+	p, _ := currency.ParsePercent("20,5 %", currency.NewLocale("de"))
+	a, _ := currency.NewAmount("100", "EUR")
+	a, _ = a.AddPercent(p)
+	if a.Number() != "120.5" {
+		t.Errorf("got %v, want 120.5", a.Number())
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+//go:build go1.18
+// +build go1.18
+
+package currency_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanz/currency"
+)
+
+func FuzzFormatter_Format(f *testing.F) {
+	f.Add("1234.59", "USD", "en")
+	f.Add("-1234.59", "EUR", "de-CH")
+	f.Add("0", "JPY", "ja")
+	f.Add("1E+30", "RSD", "sr-Latn")
+	f.Add("12345678.90", "USD", "ar")
+	f.Add("0.000000000000000000001", "KWD", "hi")
+	f.Add("-0", "", "")
+	f.Add("NaN", "USD", "en")
+	f.Add("Infinity", "USD", "en")
+	f.Add("1E+100000", "USD", "en")
+	f.Add("-1E+100000", "USD", "en")
+	f.Fuzz(func(t *testing.T, n, currencyCode, localeID string) {
+		locale := currency.NewLocale(localeID)
+		formatter := currency.NewFormatter(locale)
+		formatter.Format(currency.Amount{})
+
+		amount, err := currency.NewAmount(n, currencyCode)
+		if err != nil {
+			return
+		}
+		formatted := formatter.Format(amount)
+		checkFormatted(t, formatter, amount, formatted)
+		checkParsed(t, formatter, formatted, currencyCode)
+
+		formatter.MinDigits = 0
+		formatter.MaxDigits = currency.DefaultDigits
+		formatter.NoGrouping = true
+		formatter.AddPlusSign = true
+		formatter.CurrencyDisplay = currency.DisplayCode
+		formatted = formatter.Format(amount)
+		checkFormatted(t, formatter, amount, formatted)
+		checkParsed(t, formatter, formatted, currencyCode)
+	})
+}
+
+// checkParsed checks that the formatted amount can be parsed back,
+// which fails for misplaced grouping separators.
+//
+// Locales without any format (e.g. an empty locale) are skipped.
+func checkParsed(t *testing.T, formatter *currency.Formatter, formatted, currencyCode string) {
+	t.Helper()
+	if formatter.ResolvedLocale().IsEmpty() {
+		return
+	}
+	if _, err := formatter.Parse(formatted, currencyCode); err != nil {
+		t.Errorf("unexpected error for %q: %v", formatted, err)
+	}
+}
+
+// checkFormatted checks that the formatted amount has no NaN or exponent.
+func checkFormatted(t *testing.T, formatter *currency.Formatter, amount currency.Amount, formatted string) {
+	t.Helper()
+	number := formatter.FormatSeparate(amount).Number
+	if strings.Contains(formatted, "NaN") || strings.ContainsAny(number, "Ee") {
+		t.Errorf("got %q for %v", formatted, amount)
+	}
+}
@@ -3,7 +3,22 @@
 
 package currency
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
+
+// InvalidLocaleError is returned when a locale ID is malformed or unrecognized.
+type InvalidLocaleError struct {
+	ID string
+}
+
+func (e InvalidLocaleError) Error() string {
+	return fmt.Sprintf("invalid locale %q", e.ID)
+}
+
+// knownLanguages contains the languages found in the CLDR data.
+var knownLanguages = collectLanguages()
 
 // Locale represents a Unicode locale identifier.
 type Locale struct {
@@ -13,6 +28,10 @@ type Locale struct {
 }
 
 // NewLocale creates a new Locale from its string representation.
+//
+// Accepts BCP 47 language tags, such as those found in Accept-Language
+// headers. Extensions ("en-US-u-ca-gregory") and variants are ignored.
+// Malformed IDs result in an empty locale.
 func NewLocale(id string) Locale {
 	// Normalize the ID ("SR_rs_LATN" => "sr-Latn-RS").
 	id = strings.ToLower(id)
@@ -20,10 +39,18 @@ func NewLocale(id string) Locale {
 	locale := Locale{}
 	for i, part := range strings.Split(id, "-") {
 		if i == 0 {
+			if !isLanguage(part) {
+				return Locale{}
+			}
 			locale.Language = part
 			continue
 		}
 		partLen := len(part)
+		if partLen == 1 {
+			// A singleton starts an extension ("-u-", "-t-") or a
+			// private use section ("-x-"), which run until the end.
+			break
+		}
 		if partLen == 4 {
 			locale.Script = strings.Title(part)
 			continue
@@ -37,20 +64,157 @@ func NewLocale(id string) Locale {
 	return locale
 }
 
-// String returns the string representation of l.
-func (l Locale) String() string {
-	b := strings.Builder{}
-	b.WriteString(l.Language)
-	if l.Script != "" {
-		b.WriteString("-")
-		b.WriteString(l.Script)
+// rtlLanguages lists languages written right-to-left by default.
+var rtlLanguages = []string{
+	"ar", "ckb", "dv", "fa", "he", "ks", "lrc", "mzn", "ps", "sd", "syr", "ug", "ur", "yi",
+}
+
+// rtlScripts lists scripts written right-to-left.
+var rtlScripts = []string{
+	"Adlm", "Arab", "Hebr", "Mand", "Nkoo", "Rohg", "Samr", "Syrc", "Thaa",
+}
+
+// ParseLocale creates a new Locale from a BCP 47 language tag,
+// such as "en-US" or "zh-Hant-TW".
+//
+// Unlike NewLocale, ParseLocale returns an InvalidLocaleError if the ID
+// is malformed, its subtags are out of order, or its language is not
+// found in the CLDR data (e.g. "xx-YY"). Territories are only checked
+// for their syntax, with the exception of "UK", which is a common typo,
+// and is normalized to "GB". Variants and extensions are ignored.
+func ParseLocale(id string) (Locale, error) {
+	parts := strings.Split(strings.ReplaceAll(id, "_", "-"), "-")
+	locale := Locale{Language: strings.ToLower(parts[0])}
+	if !isLanguage(locale.Language) || !knownLanguages[locale.Language] {
+		return Locale{}, InvalidLocaleError{id}
 	}
-	if l.Territory != "" {
-		b.WriteString("-")
-		b.WriteString(l.Territory)
+	parts = parts[1:]
+	if len(parts) > 0 && len(parts[0]) == 4 && isAlpha(parts[0]) {
+		locale.Script = strings.Title(strings.ToLower(parts[0]))
+		parts = parts[1:]
+	}
+	if len(parts) > 0 && isTerritory(parts[0]) {
+		locale.Territory = strings.ToUpper(parts[0])
+		if locale.Territory == "UK" {
+			locale.Territory = "GB"
+		}
+		parts = parts[1:]
+	}
+	// Variants come first, followed by extensions ("-u-ca-gregory").
+	// Each extension starts with a singleton, and has at least one subtag.
+	extension, pending := false, false
+	for _, part := range parts {
+		switch {
+		case len(part) == 1 && isAlphanumeric(part) && !pending:
+			extension, pending = true, true
+		case extension && len(part) >= 2 && len(part) <= 8 && isAlphanumeric(part):
+			pending = false
+		case !extension && isVariant(part):
+		default:
+			return Locale{}, InvalidLocaleError{id}
+		}
+	}
+	if pending {
+		return Locale{}, InvalidLocaleError{id}
 	}
 
-	return b.String()
+	return locale, nil
+}
+
+// collectLanguages returns the languages found in the CLDR data.
+func collectLanguages() map[string]bool {
+	languages := make(map[string]bool)
+	add := func(localeID string) {
+		languages[strings.SplitN(localeID, "-", 2)[0]] = true
+	}
+	for localeID := range currencyFormats {
+		add(localeID)
+	}
+	for _, symbols := range currencySymbols {
+		for _, symbol := range symbols {
+			for _, localeID := range symbol.locales {
+				add(localeID)
+			}
+		}
+	}
+	for localeID, parentID := range parentLocales {
+		add(localeID)
+		add(parentID)
+	}
+	return languages
+}
+
+// isAlpha returns whether s consists of ASCII letters.
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isAlphanumeric returns whether s consists of ASCII letters and digits.
+func isAlphanumeric(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (r < '0' || r > '9') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// isTerritory returns whether s is a syntactically valid territory subtag.
+func isTerritory(s string) bool {
+	if len(s) == 2 {
+		return isAlpha(s)
+	}
+	if len(s) == 3 {
+		for _, r := range s {
+			if r < '0' || r > '9' {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isVariant returns whether s is a syntactically valid variant subtag.
+func isVariant(s string) bool {
+	if len(s) == 4 {
+		return s[0] >= '0' && s[0] <= '9' && isAlphanumeric(s)
+	}
+	return len(s) >= 5 && len(s) <= 8 && isAlphanumeric(s)
+}
+
+// isLanguage returns whether s is a valid language subtag.
+func isLanguage(s string) bool {
+	if len(s) < 2 || len(s) > 8 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the string representation of l.
+func (l Locale) String() string {
+	// Concatenate directly instead of using a strings.Builder,
+	// allocating at most once. String is called on every parent lookup.
+	switch {
+	case l.Script == "" && l.Territory == "":
+		return l.Language
+	case l.Script == "":
+		return l.Language + "-" + l.Territory
+	case l.Territory == "":
+		return l.Language + "-" + l.Script
+	default:
+		return l.Language + "-" + l.Script + "-" + l.Territory
+	}
 }
 
 // MarshalText implements the encoding.TextMarshaler interface.
@@ -69,6 +233,17 @@ func (l Locale) IsEmpty() bool {
 	return l.Language == "" && l.Script == "" && l.Territory == ""
 }
 
+// IsRTL returns whether l is written right-to-left.
+//
+// The script takes precedence over the language, e.g. "az-Arab" is RTL,
+// while "az" and "az-Latn" are not.
+func (l Locale) IsRTL() bool {
+	if l.Script != "" {
+		return contains(rtlScripts, l.Script)
+	}
+	return contains(rtlLanguages, l.Language)
+}
+
 // GetParent returns the parent locale for l.
 //
 //	Order:
@@ -4,6 +4,7 @@
 package currency_test
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/bojanz/currency"
@@ -25,6 +26,17 @@ func TestNewLocale(t *testing.T) {
 		{"SR_rs_LATN", currency.Locale{Language: "sr", Script: "Latn", Territory: "RS"}},
 		// ID with a variant. Variants are unsupported and ignored.
 		{"ca-ES-VALENCIA", currency.Locale{Language: "ca", Territory: "ES"}},
+		// BCP 47 tags, with extensions ignored.
+		{"zh-Hant-TW", currency.Locale{Language: "zh", Script: "Hant", Territory: "TW"}},
+		{"en-US-u-ca-gregory", currency.Locale{Language: "en", Territory: "US"}},
+		{"de-u-co-phonebk", currency.Locale{Language: "de"}},
+		{"sr-Latn-t-sr-cyrl", currency.Locale{Language: "sr", Script: "Latn"}},
+		{"en-x-private", currency.Locale{Language: "en"}},
+		// Malformed IDs.
+		{"-US", currency.Locale{}},
+		{"e", currency.Locale{}},
+		{"12-US", currency.Locale{}},
+		{"*", currency.Locale{}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id, func(t *testing.T) {
@@ -36,6 +48,57 @@ func TestNewLocale(t *testing.T) {
 	}
 }
 
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		id   string
+		want currency.Locale
+	}{
+		{"en", currency.Locale{Language: "en"}},
+		{"en-US", currency.Locale{Language: "en", Territory: "US"}},
+		{"en_us", currency.Locale{Language: "en", Territory: "US"}},
+		{"es-419", currency.Locale{Language: "es", Territory: "419"}},
+		{"zh-Hant-TW", currency.Locale{Language: "zh", Script: "Hant", Territory: "TW"}},
+		{"sr-latn", currency.Locale{Language: "sr", Script: "Latn"}},
+		{"ca-ES-valencia", currency.Locale{Language: "ca", Territory: "ES"}},
+		{"de-DE-1996", currency.Locale{Language: "de", Territory: "DE"}},
+		{"en-US-u-ca-gregory", currency.Locale{Language: "en", Territory: "US"}},
+		{"en-US-u-ca-gregory-x-private", currency.Locale{Language: "en", Territory: "US"}},
+		// UK is normalized to GB.
+		{"en-UK", currency.Locale{Language: "en", Territory: "GB"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, err := currency.ParseLocale(tt.id)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, id := range []string{
+		"", "xx-YY", "e", "-US", "en--US", "en-US-", "US-en", "SR_rs_LATN",
+		"en-u", "en-u-x-private", "en-USA", "en-US-abc", "en-US-u-ca-gregory-", "en-US-!",
+	} {
+		t.Run(id, func(t *testing.T) {
+			_, err := currency.ParseLocale(id)
+			if e, ok := err.(currency.InvalidLocaleError); ok {
+				if e.ID != id {
+					t.Errorf("got %v, want %v", e.ID, id)
+				}
+				wantError := fmt.Sprintf("invalid locale %q", id)
+				if e.Error() != wantError {
+					t.Errorf("got %v, want %v", e.Error(), wantError)
+				}
+			} else {
+				t.Errorf("got %T, want currency.InvalidLocaleError", err)
+			}
+		})
+	}
+}
+
 func TestLocale_String(t *testing.T) {
 	tests := []struct {
 		locale currency.Locale
@@ -126,6 +189,36 @@ func TestLocale_IsEmpty(t *testing.T) {
 	}
 }
 
+func TestLocale_IsRTL(t *testing.T) {
+	tests := []struct {
+		id   string
+		want bool
+	}{
+		{"ar", true},
+		{"ar-EG", true},
+		{"he", true},
+		{"fa-IR", true},
+		{"ur", true},
+		{"az-Arab", true},
+		{"pa-Arab-PK", true},
+		{"ff-Adlm", true},
+		{"en", false},
+		{"de-CH", false},
+		{"az", false},
+		{"az-Latn", false},
+		{"sd-Deva", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got := currency.NewLocale(tt.id).IsRTL()
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestLocale_GetParent(t *testing.T) {
 	tests := []struct {
 		id   string
@@ -0,0 +1,159 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/cockroachdb/apd/v3"
+)
+
+// MissingRateError is returned when there is no exchange rate between two currencies.
+type MissingRateError struct {
+	From string
+	To   string
+}
+
+func (e MissingRateError) Error() string {
+	return fmt.Sprintf("no exchange rate from %q to %q", e.From, e.To)
+}
+
+// RateTable holds exchange rates between currencies.
+type RateTable struct {
+	// Base is the currency used to convert between two currencies
+	// which have no direct rate, e.g. "USD".
+	// Optional. Conversions only use direct rates if empty.
+	Base string
+	// Rates maps source currency codes to target currency codes and
+	// their exchange rates. For example, ["USD"]["EUR"] = "0.91" means
+	// that 1 USD is converted to 0.91 EUR.
+	Rates map[string]map[string]string
+}
+
+// NewRateTable creates a new rate table with the given base currency.
+func NewRateTable(base string) *RateTable {
+	t := &RateTable{
+		Base:  base,
+		Rates: make(map[string]map[string]string),
+	}
+	return t
+}
+
+// ParseRateTable creates a new rate table from a JSON payload.
+//
+// The payload has the shape {"base":"USD","rates":{"EUR":"0.92"}},
+// as returned by common exchange rate feeds. Rates can be JSON strings
+// or numbers, and are kept as decimal strings to preserve precision.
+// The table's base currency is the payload base, or the given base
+// currency if the payload has none. Only rates from the base currency
+// to each listed currency are set, Convert inverts them as needed
+// (e.g. EUR to JPY for a USD based feed).
+func ParseRateTable(r io.Reader, base string) (*RateTable, error) {
+	var payload struct {
+		Base  string                 `json:"base"`
+		Rates map[string]json.Number `json:"rates"`
+	}
+	if err := json.NewDecoder(r).Decode(&payload); err != nil {
+		return nil, err
+	}
+	if payload.Base == "" {
+		payload.Base = base
+	}
+	t := NewRateTable(payload.Base)
+	for to, rate := range payload.Rates {
+		if err := t.Set(payload.Base, to, rate.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+// Set sets the exchange rate between two currencies.
+//
+// The rate must be a positive number.
+func (t *RateTable) Set(from, to, rate string) error {
+	if from == "" || !IsValid(from) {
+		return InvalidCurrencyCodeError{from}
+	}
+	if to == "" || !IsValid(to) {
+		return InvalidCurrencyCodeError{to}
+	}
+	number := apd.Decimal{}
+	if _, _, err := number.SetString(rate); err != nil || number.Form != apd.Finite || number.Sign() <= 0 {
+		return InvalidNumberError{rate}
+	}
+	from, to = canonicalCode(from), canonicalCode(to)
+	if t.Rates[from] == nil {
+		t.Rates[from] = make(map[string]string)
+	}
+	t.Rates[from][to] = rate
+
+	return nil
+}
+
+// Rate returns the exchange rate between two currencies.
+//
+// Only returns direct rates.
+func (t *RateTable) Rate(from, to string) (rate string, ok bool) {
+	rate, ok = t.Rates[canonicalCode(from)][canonicalCode(to)]
+	return rate, ok
+}
+
+// Convert converts amount to a different currency.
+//
+// Uses the direct rate if available. Otherwise converts the amount to
+// the base currency first, then to the target currency. Each step via
+// the base currency can also use the inverse of the opposite rate, so
+// that a table with only base rates (USD to EUR, USD to JPY) can convert
+// EUR to JPY. The result is not rounded, full precision is kept until the
+// final result, apart from the division needed by inverse rates.
+func (t *RateTable) Convert(amount Amount, to string) (Amount, error) {
+	if to == "" || !IsValid(to) {
+		return Amount{}, InvalidCurrencyCodeError{to}
+	}
+	from, to, base := amount.CurrencyCode(), canonicalCode(to), canonicalCode(t.Base)
+	if from == to {
+		return amount, nil
+	}
+	if rate, ok := t.Rate(from, to); ok {
+		return amount.Convert(to, rate)
+	}
+	if base == "" || base == from || base == to {
+		return Amount{}, MissingRateError{from, to}
+	}
+	amount, ok, err := t.convertStep(amount, base)
+	if !ok {
+		return Amount{}, MissingRateError{from, to}
+	} else if err != nil {
+		return Amount{}, err
+	}
+	amount, ok, err = t.convertStep(amount, to)
+	if !ok {
+		return Amount{}, MissingRateError{from, to}
+	}
+
+	return amount, err
+}
+
+// convertStep converts amount to a different currency using the direct
+// rate, or the inverse of the opposite rate, if available.
+func (t *RateTable) convertStep(amount Amount, to string) (result Amount, ok bool, err error) {
+	from := amount.CurrencyCode()
+	if rate, ok := t.Rate(from, to); ok {
+		result, err = amount.Convert(to, rate)
+		return result, true, err
+	}
+	if rate, ok := t.Rate(to, from); ok {
+		result, err = amount.Div(rate)
+		if err != nil {
+			return Amount{}, true, err
+		}
+		result, err = result.WithCurrency(to)
+		return result, true, err
+	}
+	return Amount{}, false, nil
+}
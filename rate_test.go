@@ -0,0 +1,249 @@
+// Copyright (c) 2020 Bojan Zivanovic and contributors
+// SPDX-License-Identifier: MIT
+
+package currency_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bojanz/currency"
+)
+
+func TestParseRateTable(t *testing.T) {
+	_, err := currency.ParseRateTable(strings.NewReader(`{"base":"USD","rates":{"xyz":"0.92"}}`), "USD")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	_, err = currency.ParseRateTable(strings.NewReader(`{"base":"USD","rates":{"EUR":"INVALID"}}`), "USD")
+	if err == nil {
+		t.Errorf("expected error for an invalid rate")
+	}
+	_, err = currency.ParseRateTable(strings.NewReader(`{"base":`), "USD")
+	if err == nil {
+		t.Errorf("expected error for invalid JSON")
+	}
+
+	payload := `{"base":"USD","rates":{"EUR":"0.92","JPY":149.50,"GBP":"0.791234567890123456789"}}`
+	rates, err := currency.ParseRateTable(strings.NewReader(payload), "USD")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if rates.Base != "USD" {
+		t.Errorf("got %v, want USD", rates.Base)
+	}
+	tests := []struct {
+		to   string
+		want string
+	}{
+		{"EUR", "0.92"},
+		{"JPY", "149.50"},
+		// Rates are not parsed as floats.
+		{"GBP", "0.791234567890123456789"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.to, func(t *testing.T) {
+			rate, ok := rates.Rate("USD", tt.to)
+			if rate != tt.want || !ok {
+				t.Errorf("got %v, %v, want %v, true", rate, ok, tt.want)
+			}
+		})
+	}
+	a, _ := currency.NewAmount("20.99", "USD")
+	b, err := rates.Convert(a, "EUR")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "19.3108 EUR" {
+		t.Errorf("got %v, want 19.3108 EUR", b)
+	}
+	// Cross rates use the inverse of the base rates.
+	a, _ = currency.NewAmount("20.99", "EUR")
+	b, err = rates.Convert(a, "JPY")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if b.String() != "3410.8750000000000005850 JPY" {
+		t.Errorf("got %v, want 3410.8750000000000005850 JPY", b)
+	}
+
+	// The base currency is used if the payload has none.
+	rates, err = currency.ParseRateTable(strings.NewReader(`{"rates":{"RSD":"117.2"}}`), "EUR")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if rates.Base != "EUR" {
+		t.Errorf("got %v, want EUR", rates.Base)
+	}
+	rate, ok := rates.Rate("EUR", "RSD")
+	if rate != "117.2" || !ok {
+		t.Errorf("got %v, %v, want 117.2, true", rate, ok)
+	}
+
+	// The payload base takes precedence.
+	rates, err = currency.ParseRateTable(strings.NewReader(`{"base":"EUR","rates":{"USD":"1.09"}}`), "USD")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if rates.Base != "EUR" {
+		t.Errorf("got %v, want EUR", rates.Base)
+	}
+	rate, ok = rates.Rate("EUR", "USD")
+	if rate != "1.09" || !ok {
+		t.Errorf("got %v, %v, want 1.09, true", rate, ok)
+	}
+}
+
+func TestRateTable_Set(t *testing.T) {
+	rates := currency.NewRateTable("USD")
+	err := rates.Set("xyz", "EUR", "0.91")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	err = rates.Set("USD", "xyz", "0.91")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+	for _, rate := range []string{"INVALID", "NaN", "Infinity", "-Infinity", "0", "-0", "-1"} {
+		err = rates.Set("USD", "EUR", rate)
+		if e, ok := err.(currency.InvalidNumberError); ok {
+			if e.Number != rate {
+				t.Errorf("got %v, want %v", e.Number, rate)
+			}
+		} else {
+			t.Errorf("got %T, want currency.InvalidNumberError", err)
+		}
+	}
+	if _, ok := rates.Rate("USD", "EUR"); ok {
+		t.Errorf("expected invalid rates to not be set")
+	}
+
+	err = rates.Set("USD", "EUR", "0.91")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	rate, ok := rates.Rate("USD", "EUR")
+	if rate != "0.91" || !ok {
+		t.Errorf("got %v, %v, want 0.91, true", rate, ok)
+	}
+	rate, ok = rates.Rate("EUR", "USD")
+	if rate != "" || ok {
+		t.Errorf("got %v, %v, want \"\", false", rate, ok)
+	}
+
+	// Currency codes are case-insensitive.
+	err = rates.Set("usd", "gbp", "0.79")
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	rate, ok = rates.Rate("USD", "GBP")
+	if rate != "0.79" || !ok {
+		t.Errorf("got %v, %v, want 0.79, true", rate, ok)
+	}
+	rate, ok = rates.Rate("Usd", "eur")
+	if rate != "0.91" || !ok {
+		t.Errorf("got %v, %v, want 0.91, true", rate, ok)
+	}
+}
+
+func TestRateTable_Convert(t *testing.T) {
+	rates := currency.NewRateTable("USD")
+	rates.Set("USD", "EUR", "0.91")
+	rates.Set("USD", "JPY", "149.5")
+	rates.Set("EUR", "USD", "1.0989")
+	rates.Set("GBP", "USD", "1.2234")
+	rates.Set("EUR", "RSD", "117.2")
+
+	a, _ := currency.NewAmount("20.99", "EUR")
+	_, err := rates.Convert(a, "xyz")
+	if e, ok := err.(currency.InvalidCurrencyCodeError); ok {
+		if e.CurrencyCode != "xyz" {
+			t.Errorf("got %v, want xyz", e.CurrencyCode)
+		}
+	} else {
+		t.Errorf("got %T, want currency.InvalidCurrencyCodeError", err)
+	}
+
+	tests := []struct {
+		number       string
+		currencyCode string
+		to           string
+		want         string
+	}{
+		// Same currency.
+		{"20.99", "EUR", "EUR", "20.99 EUR"},
+		// Direct rate.
+		{"20.99", "USD", "EUR", "19.1009 EUR"},
+		{"20.99", "EUR", "USD", "23.065911 USD"},
+		{"20.99", "EUR", "RSD", "2460.028 RSD"},
+		// Conversion via the base currency.
+		{"20.99", "GBP", "EUR", "23.36804106 EUR"},
+		{"20.99", "EUR", "JPY", "3448.3536945 JPY"},
+		{"20.99", "GBP", "JPY", "3839.0353170 JPY"},
+		// Conversion via the inverse of a base rate.
+		{"20.99", "JPY", "EUR", "0.127765217391304347855 EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount(tt.number, tt.currencyCode)
+			got, err := rates.Convert(a, tt.to)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if got.String() != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// Missing rates.
+	missingTests := []struct {
+		from string
+		to   string
+	}{
+		{"USD", "GBP"},
+		{"RSD", "EUR"},
+		{"GBP", "RSD"},
+	}
+	for _, tt := range missingTests {
+		t.Run("", func(t *testing.T) {
+			a, _ := currency.NewAmount("20.99", tt.from)
+			_, err := rates.Convert(a, tt.to)
+			if e, ok := err.(currency.MissingRateError); ok {
+				if e.From != tt.from {
+					t.Errorf("got %v, want %v", e.From, tt.from)
+				}
+				if e.To != tt.to {
+					t.Errorf("got %v, want %v", e.To, tt.to)
+				}
+				wantError := `no exchange rate from "` + tt.from + `" to "` + tt.to + `"`
+				if e.Error() != wantError {
+					t.Errorf("got %v, want %v", e.Error(), wantError)
+				}
+			} else {
+				t.Errorf("got %T, want currency.MissingRateError", err)
+			}
+		})
+	}
+
+	// No base currency.
+	rates.Base = ""
+	a, _ = currency.NewAmount("20.99", "GBP")
+	_, err = rates.Convert(a, "EUR")
+	if _, ok := err.(currency.MissingRateError); !ok {
+		t.Errorf("got %T, want currency.MissingRateError", err)
+	}
+}